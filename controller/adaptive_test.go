@@ -0,0 +1,39 @@
+package controller
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPollSchedulerInterval(t *testing.T) {
+	intervals := pollIntervals{
+		normal:     time.Minute,
+		fast:       5 * time.Second,
+		fastWindow: time.Minute,
+		idle:       5 * time.Minute,
+		idleAfter:  time.Hour,
+	}
+	now := time.Now()
+
+	tests := []struct {
+		name  string
+		entry pollEntry
+		want  time.Duration
+	}{
+		{"normal", pollEntry{lastChange: now.Add(-time.Minute)}, time.Minute},
+		{"fast window", pollEntry{lastChange: now, fastUntil: now.Add(time.Second)}, 5 * time.Second},
+		{"fast window over", pollEntry{lastChange: now.Add(-2 * time.Minute), fastUntil: now.Add(-time.Second)}, time.Minute},
+		{"idle", pollEntry{lastChange: now.Add(-2 * time.Hour)}, 5 * time.Minute},
+		{"fast wins over idle", pollEntry{lastChange: now.Add(-2 * time.Hour), fastUntil: now.Add(time.Second)}, 5 * time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newPollScheduler(intervals, 1, nil)
+
+			if got := s.interval(&tt.entry, now); got != tt.want {
+				t.Errorf("interval() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -3,6 +3,8 @@ package controller
 import (
 	"fmt"
 	"log"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/nikolai5slo/ttlock2mqtt/credentials"
@@ -18,15 +20,68 @@ type Controller struct {
 	refreshRate   time.Duration
 	ttlockService ttlock.Service
 
-	lastRefresh     time.Time
-	introducedLocks locks.LockList
+	detailsRefreshRate  time.Duration
+	syncRate            time.Duration
+	recordsRefreshRate  time.Duration
+	gatewaysRefreshRate time.Duration
+	pollWorkers         int
+	pollIntervals       pollIntervals
+
+	scheduler *pollScheduler
+	limiter   *rateLimiter
+
+	reload              chan struct{}
+	lastRefresh         time.Time
+	lastDetailsRefresh  time.Time
+	lastSync            time.Time
+	detailsDue          atomic.Bool
+	mqttConnected       atomic.Bool
+	lastRecordsRefresh  time.Time
+	lastGatewaysRefresh time.Time
+	introducedLocks     locks.LockList
+	creds               credentials.CredentialsList
+	locksMu             sync.RWMutex
+
+	// Lock date of the last seen record per lock
+	lastRecords map[int32]*recordMark
+	recordsMu   sync.Mutex
+
+	// Last reported state and commands waiting for confirmation per lock
+	lockStates     map[int32]ttlock.LockStatus
+	pending        map[int32]pendingCommand
+	commandTimeout time.Duration
+	statesMu       sync.Mutex
+
+	// Last known passage mode configuration per lock
+	passageModes map[int32]ttlock.PassageMode
+	passageMu    sync.Mutex
+
+	// Gateways of accounts with managed locks
+	gateways   map[int32]gatewayEntry
+	gatewaysMu sync.Mutex
 }
 
 type Conf func(*Controller) error
 
 func New(cfg ...Conf) (*Controller, error) {
 	s := &Controller{
-		refreshRate: 60 * time.Second,
+		refreshRate:         60 * time.Second,
+		detailsRefreshRate:  time.Hour,
+		syncRate:            6 * time.Hour,
+		recordsRefreshRate:  5 * time.Minute,
+		gatewaysRefreshRate: 5 * time.Minute,
+		pollWorkers:         4,
+		pollIntervals: pollIntervals{
+			jitter:     0.1,
+			maxBackoff: 30 * time.Minute,
+		},
+		lastRecords:    map[int32]*recordMark{},
+		lockStates:     map[int32]ttlock.LockStatus{},
+		pending:        map[int32]pendingCommand{},
+		commandTimeout: 30 * time.Second,
+		passageModes:   map[int32]ttlock.PassageMode{},
+		gateways:       map[int32]gatewayEntry{},
+		reload:         make(chan struct{}, 1),
 	}
 
 	for _, c := range cfg {
@@ -35,6 +90,14 @@ func New(cfg ...Conf) (*Controller, error) {
 		}
 	}
 
+	s.pollIntervals.normal = s.refreshRate
+	s.scheduler = newPollScheduler(s.pollIntervals, s.pollWorkers, s.pollLock)
+
+	if s.mqtt != nil {
+		s.mqtt.SetReannounceHandler(s.reannounce)
+		s.mqtt.SetConnectionHandler(s.mqttConnected.Store)
+	}
+
 	return s, nil
 }
 
@@ -66,6 +129,77 @@ func WithRefreshRate(d time.Duration) Conf {
 	}
 }
 
+func WithDetailsRefreshRate(d time.Duration) Conf {
+	return func(c *Controller) error {
+		c.detailsRefreshRate = d
+		return nil
+	}
+}
+
+// WithSyncRate sets how often lock metadata is synced from the TTLock account into storage
+func WithSyncRate(d time.Duration) Conf {
+	return func(c *Controller) error {
+		c.syncRate = d
+		return nil
+	}
+}
+
+func WithRecordsRefreshRate(d time.Duration) Conf {
+	return func(c *Controller) error {
+		c.recordsRefreshRate = d
+		return nil
+	}
+}
+
+func WithGatewaysRefreshRate(d time.Duration) Conf {
+	return func(c *Controller) error {
+		c.gatewaysRefreshRate = d
+		return nil
+	}
+}
+
+// WithPolling configures concurrent lock polling, jitter is a fraction of the refresh rate
+func WithPolling(workers int, jitter float64, maxBackoff time.Duration) Conf {
+	return func(c *Controller) error {
+		if jitter < 0 || jitter >= 1 {
+			return fmt.Errorf("poll jitter must be between 0 and 1")
+		}
+
+		c.pollWorkers = workers
+		c.pollIntervals.jitter = jitter
+		c.pollIntervals.maxBackoff = maxBackoff
+		return nil
+	}
+}
+
+// WithAdaptivePolling polls at the fast interval for fastWindow after commands, callbacks or
+// observed changes and at the idle interval once a lock did not change for idleAfter
+func WithAdaptivePolling(fast time.Duration, fastWindow time.Duration, idle time.Duration, idleAfter time.Duration) Conf {
+	return func(c *Controller) error {
+		c.pollIntervals.fast = fast
+		c.pollIntervals.fastWindow = fastWindow
+		c.pollIntervals.idle = idle
+		c.pollIntervals.idleAfter = idleAfter
+		return nil
+	}
+}
+
+// WithCommandTimeout sets how long a command may take to be confirmed before the lock is reported jammed
+func WithCommandTimeout(d time.Duration) Conf {
+	return func(c *Controller) error {
+		c.commandTimeout = d
+		return nil
+	}
+}
+
+// WithRateLimit limits background TTLock API requests per minute, 0 disables the limit
+func WithRateLimit(perMinute int) Conf {
+	return func(c *Controller) error {
+		c.limiter = newRateLimiter(perMinute)
+		return nil
+	}
+}
+
 func WithTTlockService(t ttlock.Service) Conf {
 	return func(c *Controller) error {
 		c.ttlockService = t
@@ -75,6 +209,7 @@ func WithTTlockService(t ttlock.Service) Conf {
 
 func (c *Controller) StartAutoRefresh() {
 	go c.runRefresh()
+	go c.scheduler.Run()
 }
 
 func (c *Controller) runRefresh() {
@@ -86,13 +221,24 @@ func (c *Controller) runRefresh() {
 			log.Printf("auto refresh failed: %s", err)
 		}
 
-		d := time.Until(c.lastRefresh.Add(c.refreshRate))
-		if d > 0 {
-			time.Sleep(d)
+		timer := time.NewTimer(time.Until(c.lastRefresh.Add(c.refreshRate)))
+
+		select {
+		case <-timer.C:
+		case <-c.reload:
+			timer.Stop()
 		}
 	}
 }
 
+// StorageChanged applies changes of managed locks and credentials right away
+func (c *Controller) StorageChanged() {
+	select {
+	case c.reload <- struct{}{}:
+	default:
+	}
+}
+
 func (c *Controller) Refresh() error {
 	// Read locks
 	mLocks := locks.LockList{}
@@ -112,83 +258,229 @@ func (c *Controller) Refresh() error {
 		return fmt.Errorf("cannot load credentials: %w", err)
 	}
 
-	newLocks := mLocks.Diff(c.introducedLocks)
+	c.locksMu.Lock()
+	c.creds = creds
+	c.locksMu.Unlock()
 
-	// Introduce new locks
-	for _, l := range newLocks {
-		err := c.mqtt.IntroduceLock(l)
-		if err != nil {
-			return fmt.Errorf("was not able to update the %d lock on mqtt: %w", l.LockId, err)
+	if time.Since(c.lastSync) >= c.syncRate {
+		c.lastSync = time.Now()
+
+		if synced, err := c.syncMetadata(mLocks, creds); err != nil {
+			log.Printf("lock metadata sync failed: %s", err)
+		} else {
+			mLocks = synced
 		}
+	}
 
-		getLockCallback := func(lck locks.ManagedLock) func(ls ttlock.LockStatus) {
-			return func(ls ttlock.LockStatus) {
-				cred := creds.Get(lck.CredentialsID)
+	if err := c.reconcile(mLocks); err != nil {
+		return err
+	}
 
-				if cred == nil {
-					log.Printf("cannot find credentials: %d", lck.CredentialsID)
-				}
+	if c.detailsDue.Swap(false) || time.Since(c.lastDetailsRefresh) >= c.detailsRefreshRate {
+		c.lastDetailsRefresh = time.Now()
+		c.refreshDetails(creds)
+	}
 
-				var err error
+	if time.Since(c.lastRecordsRefresh) >= c.recordsRefreshRate {
+		c.lastRecordsRefresh = time.Now()
+		c.refreshRecords(creds)
+	}
 
-				if ls == ttlock.Locked {
-					err = c.ttlockService.Lock(*cred, lck.Lock)
-				} else if ls == ttlock.Unlocked {
-					err = c.ttlockService.Unlock(*cred, lck.Lock)
-				}
+	if time.Since(c.lastGatewaysRefresh) >= c.gatewaysRefreshRate {
+		c.lastGatewaysRefresh = time.Now()
+		c.refreshGateways(creds)
+	}
 
-				if err != nil {
-					log.Printf("failed to lock/unlock [%d]: %s", lck.LockId, err)
-				} else {
-					err = c.mqtt.UpdateLockStatus(lck, ls)
+	return nil
+}
 
-					if err != nil {
-						log.Printf("failed to update lock status: %s", err)
-					}
-				}
-			}
+// reannounce re-publishes configs of all introduced locks and gateways and refreshes their states
+func (c *Controller) reannounce() {
+	for _, g := range c.getGateways() {
+		if err := c.mqtt.IntroduceGateway(g); err != nil {
+			log.Printf("failed to re-announce gateway [%d]: %s", g.GatewayId, err)
+			continue
 		}
 
-		// Monitor lock commands
-		if err := c.mqtt.MqttLockCommandCallback(l, getLockCallback(l)); err != nil {
-			log.Printf("Failed to monitor lock: %s", err)
-		} else {
-			log.Printf("introduced new lock: %d", l.LockId)
-			c.introducedLocks = c.introducedLocks.Add(l)
+		if err := c.mqtt.UpdateGateway(g); err != nil {
+			log.Printf("failed to update gateway [%d]: %s", g.GatewayId, err)
+		}
+	}
+
+	introducedLocks := c.getIntroducedLocks()
+
+	if len(introducedLocks) == 0 {
+		return
+	}
+
+	log.Printf("re-announcing %d locks", len(introducedLocks))
+
+	for _, l := range introducedLocks {
+		if err := c.mqtt.IntroduceLock(l); err != nil {
+			log.Printf("failed to re-announce lock [%d]: %s", l.LockId, err)
+			continue
+		}
+
+		if l.ElectricQuantity != nil {
+			if err := c.mqtt.UpdateLockBattery(l, *l.ElectricQuantity); err != nil {
+				log.Printf("failed to update lock battery: %s", err)
+			}
 		}
+
+		// Availability and state are published by the poll
+		c.scheduler.PollNow(l.LockId)
 	}
 
-	///
-	// Get lock statuses
-	//
-	for i, l := range c.introducedLocks {
+	// Replace the stored battery snapshots with fresh values on next refresh
+	c.detailsDue.Store(true)
+}
+
+// refreshDetails re-fetches lock details and publishes battery levels
+func (c *Controller) refreshDetails(creds credentials.CredentialsList) {
+	for _, l := range c.getIntroducedLocks() {
 		cred := creds.Get(l.CredentialsID)
 
 		if cred == nil {
-			return fmt.Errorf("cannot find credentials: %d", l.CredentialsID)
+			log.Printf("cannot find credentials: %d", l.CredentialsID)
+			continue
 		}
 
-		status, err := c.ttlockService.GetLockStatus(*cred, l.Lock)
+		c.limiter.Wait()
+
+		details, err := c.ttlockService.GetLockDetails(*cred, l.Lock)
 
 		if err != nil {
-			log.Printf("cannot get lock status [%d]: %s", l.LockId, err)
+			log.Printf("cannot get lock details [%d]: %s", l.LockId, err)
+			continue
 		}
 
-		err = c.mqtt.UpdateLockStatus(l, status)
+		// Only take what storage does not know, storage changes are applied by reconcile
+		updated := l
+		if details.ElectricQuantity != nil {
+			updated.ElectricQuantity = details.ElectricQuantity
+		}
+		updated.FeatureValue = details.FeatureValue
+		updated.ModelNum = details.ModelNum
+		updated.FirmwareRevision = details.FirmwareRevision
+		updated.HardwareRevision = details.HardwareRevision
+
+		c.locksMu.Lock()
+		if c.introducedLocks.Find(l.LockId) >= 0 {
+			c.introducedLocks = c.introducedLocks.Add(updated)
+		}
+		c.locksMu.Unlock()
+
+		// Device info comes from details, announce it once it is known or changed
+		if stringValue(details.FirmwareRevision) != stringValue(l.FirmwareRevision) ||
+			stringValue(details.HardwareRevision) != stringValue(l.HardwareRevision) ||
+			stringValue(details.FeatureValue) != stringValue(l.FeatureValue) ||
+			stringValue(details.ModelNum) != stringValue(l.ModelNum) {
+			if err := c.mqtt.IntroduceLock(updated); err != nil {
+				log.Printf("failed to update lock device info [%d]: %s", l.LockId, err)
+			}
+		}
 
-		if err != nil {
-			log.Printf("failed to update lock status: %s", err)
+		if ttlock.SupportsFeature(updated.Lock, ttlock.FeaturePassageMode) {
+			if _, err := c.refreshPassageMode(updated, *cred); err != nil {
+				log.Printf("cannot refresh passage mode [%d]: %s", l.LockId, err)
+			}
+		}
+
+		if details.ElectricQuantity == nil {
+			continue
 		}
 
-		if i < len(c.introducedLocks)-1 {
-			time.Sleep(time.Duration(int(c.refreshRate) / len(c.introducedLocks)))
+		err = c.mqtt.UpdateLockBattery(updated, *details.ElectricQuantity)
+
+		if err != nil {
+			log.Printf("failed to update lock battery: %s", err)
 		}
 	}
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+
+	return *s
+}
+
+// Command locks or unlocks a managed lock and publishes the new state
+func (c *Controller) Command(lockID int32, ls ttlock.LockStatus) error {
+	introducedLocks := c.getIntroducedLocks()
+
+	idx := introducedLocks.Find(lockID)
+
+	if idx < 0 {
+		return fmt.Errorf("lock %d is not managed", lockID)
+	}
+
+	return c.executeCommand(introducedLocks[idx], ls)
+}
+
+func (c *Controller) executeCommand(lck locks.ManagedLock, ls ttlock.LockStatus) error {
+	creds := credentials.CredentialsList{}
+
+	if err := c.credStorage.Load(&creds); err != nil {
+		return fmt.Errorf("cannot load credentials: %w", err)
+	}
+
+	cred := creds.Get(lck.CredentialsID)
+
+	if cred == nil {
+		return fmt.Errorf("cannot find credentials: %d", lck.CredentialsID)
+	}
+
+	if ls != ttlock.Locked && ls != ttlock.Unlocked {
+		return fmt.Errorf("unsupported lock command: %d", ls)
+	}
+
+	c.startCommand(lck, ls)
+
+	var err error
+
+	if ls == ttlock.Locked {
+		err = c.ttlockService.Lock(*cred, lck.Lock)
+	} else {
+		err = c.ttlockService.Unlock(*cred, lck.Lock)
+	}
+
+	if err != nil {
+		c.failCommand(lck)
+		return err
+	}
+
+	// Confirm the commanded state from the lock
+	c.scheduler.Boost(lck.LockId)
 
 	return nil
 }
 
+func (c *Controller) getIntroducedLocks() locks.LockList {
+	c.locksMu.RLock()
+	defer c.locksMu.RUnlock()
+
+	return c.introducedLocks
+}
+
+func (c *Controller) getIntroducedLock(lockID int32) (locks.ManagedLock, *credentials.Credentials, bool) {
+	c.locksMu.RLock()
+	defer c.locksMu.RUnlock()
+
+	idx := c.introducedLocks.Find(lockID)
+
+	if idx < 0 {
+		return locks.ManagedLock{}, nil, false
+	}
+
+	l := c.introducedLocks[idx]
+
+	return l, c.creds.Get(l.CredentialsID), true
+}
+
 func (c *Controller) Close() error {
+	c.scheduler.Stop()
 	c.mqtt.Close()
 	return nil
 }
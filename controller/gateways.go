@@ -0,0 +1,154 @@
+package controller
+
+import (
+	"log"
+
+	"github.com/nikolai5slo/ttlock2mqtt/credentials"
+	"github.com/nikolai5slo/ttlock2mqtt/ttlock"
+)
+
+type gatewayEntry struct {
+	gateway       ttlock.Gateway
+	credentialsID int32
+}
+
+// refreshGateways announces gateways of accounts with managed locks and links locks to the
+// gateway with the strongest signal
+func (c *Controller) refreshGateways(creds credentials.CredentialsList) {
+	introducedLocks := c.getIntroducedLocks()
+
+	accounts := map[int32]bool{}
+	for _, l := range introducedLocks {
+		accounts[l.CredentialsID] = true
+	}
+
+	gateways := map[int32]gatewayEntry{}
+	lockGateways := map[int32]int32{}
+	lockRssi := map[int32]int32{}
+	failed := map[int32]bool{}
+
+	for credID := range accounts {
+		cred := creds.Get(credID)
+
+		if cred == nil {
+			continue
+		}
+
+		c.limiter.Wait()
+		accountGateways, err := c.ttlockService.GetGateways(*cred)
+
+		if err != nil {
+			log.Printf("cannot list gateways of %s: %s", cred.Username, err)
+			failed[credID] = true
+			continue
+		}
+
+		for _, g := range accountGateways {
+			gateways[g.GatewayId] = gatewayEntry{gateway: g, credentialsID: credID}
+
+			if g.LockNum != nil && *g.LockNum == 0 {
+				continue
+			}
+
+			c.limiter.Wait()
+			gatewayLocks, err := c.ttlockService.GetGatewayLocks(*cred, g.GatewayId)
+
+			if err != nil {
+				log.Printf("cannot list locks of gateway %d: %s", g.GatewayId, err)
+				failed[credID] = true
+				continue
+			}
+
+			for _, gl := range gatewayLocks {
+				rssi := int32Value(gl.Rssi)
+
+				if _, ok := lockGateways[gl.LockId]; ok && rssi <= lockRssi[gl.LockId] {
+					continue
+				}
+
+				lockGateways[gl.LockId] = g.GatewayId
+				lockRssi[gl.LockId] = rssi
+			}
+		}
+	}
+
+	c.gatewaysMu.Lock()
+	previous := c.gateways
+	c.gatewaysMu.Unlock()
+
+	// Keep gateways of accounts that could not be listed
+	for id, e := range previous {
+		if _, ok := gateways[id]; !ok && failed[e.credentialsID] {
+			gateways[id] = e
+		}
+	}
+
+	for id := range previous {
+		if _, ok := gateways[id]; ok {
+			continue
+		}
+
+		if err := c.mqtt.RetireGateway(id); err != nil {
+			log.Printf("failed to retire gateway [%d]: %s", id, err)
+			continue
+		}
+
+		log.Printf("retired gateway: %d", id)
+	}
+
+	for id, e := range gateways {
+		if _, ok := previous[id]; !ok {
+			// Not recorded, announced again on next refresh
+			if err := c.mqtt.IntroduceGateway(e.gateway); err != nil {
+				log.Printf("failed to introduce gateway [%d]: %s", id, err)
+				delete(gateways, id)
+				continue
+			}
+
+			log.Printf("introduced new gateway: %d", id)
+		}
+
+		if err := c.mqtt.UpdateGateway(e.gateway); err != nil {
+			log.Printf("failed to update gateway [%d]: %s", id, err)
+		}
+	}
+
+	c.gatewaysMu.Lock()
+	c.gateways = gateways
+	c.gatewaysMu.Unlock()
+
+	for _, l := range introducedLocks {
+		gatewayID, ok := lockGateways[l.LockId]
+
+		// Keep the last known gateway while the account cannot be listed
+		if !ok && failed[l.CredentialsID] {
+			continue
+		}
+
+		previousID := c.mqtt.LockGateway(l.LockId)
+
+		if previousID == gatewayID {
+			continue
+		}
+
+		c.mqtt.SetLockGateway(l.LockId, gatewayID)
+
+		if err := c.mqtt.IntroduceLock(l); err != nil {
+			// Retried on next refresh
+			c.mqtt.SetLockGateway(l.LockId, previousID)
+			log.Printf("failed to update gateway of lock [%d]: %s", l.LockId, err)
+		}
+	}
+}
+
+func (c *Controller) getGateways() []ttlock.Gateway {
+	c.gatewaysMu.Lock()
+	defer c.gatewaysMu.Unlock()
+
+	gateways := make([]ttlock.Gateway, 0, len(c.gateways))
+	for _, e := range c.gateways {
+		gateways = append(gateways, e.gateway)
+	}
+
+	return gateways
+}
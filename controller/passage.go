@@ -0,0 +1,103 @@
+package controller
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/nikolai5slo/ttlock2mqtt/credentials"
+	"github.com/nikolai5slo/ttlock2mqtt/locks"
+	"github.com/nikolai5slo/ttlock2mqtt/mqtt"
+	"github.com/nikolai5slo/ttlock2mqtt/ttlock"
+)
+
+// refreshPassageMode fetches and publishes the passage mode configuration of the lock
+func (c *Controller) refreshPassageMode(l locks.ManagedLock, cred credentials.Credentials) (ttlock.PassageMode, error) {
+	c.limiter.Wait()
+
+	p, err := c.ttlockService.GetPassageMode(cred, l.Lock)
+
+	if err != nil {
+		return p, fmt.Errorf("cannot get passage mode: %w", err)
+	}
+
+	c.setPassageMode(l, p)
+
+	return p, nil
+}
+
+func (c *Controller) setPassageMode(l locks.ManagedLock, p ttlock.PassageMode) {
+	c.passageMu.Lock()
+	c.passageModes[l.LockId] = p
+	c.passageMu.Unlock()
+
+	if err := c.mqtt.UpdatePassageMode(l, p); err != nil {
+		log.Printf("failed to update passage mode: %s", err)
+	}
+}
+
+func (c *Controller) getPassageMode(lockID int32) (ttlock.PassageMode, bool) {
+	c.passageMu.Lock()
+	defer c.passageMu.Unlock()
+
+	p, ok := c.passageModes[lockID]
+
+	return p, ok
+}
+
+// passageModeCallback applies passage mode changes from MQTT to the lock as it is currently introduced
+func (c *Controller) passageModeCallback(lockID int32) func(cmd mqtt.LockCommand, update mqtt.PassageModeUpdate) {
+	return func(cmd mqtt.LockCommand, update mqtt.PassageModeUpdate) {
+		l, cred, ok := c.getIntroducedLock(lockID)
+
+		if !ok {
+			return
+		}
+
+		start := time.Now()
+		err := c.updatePassageMode(l, cred, update)
+
+		if err != nil {
+			log.Printf("failed to configure passage mode [%d]: %s", lockID, err)
+		}
+
+		if err := c.mqtt.PublishCommandResult(l, cmd, time.Since(start), err); err != nil {
+			log.Printf("failed to publish command result: %s", err)
+		}
+	}
+}
+
+func (c *Controller) updatePassageMode(l locks.ManagedLock, cred *credentials.Credentials, update mqtt.PassageModeUpdate) error {
+	if cred == nil {
+		return fmt.Errorf("cannot find credentials: %d", l.CredentialsID)
+	}
+
+	if !ttlock.SupportsFeature(l.Lock, ttlock.FeaturePassageMode) {
+		return fmt.Errorf("lock %d does not support passage mode", l.LockId)
+	}
+
+	// Unset fields keep the configuration of the lock, the cache may be outdated by changes in the app
+	current, err := c.refreshPassageMode(l, *cred)
+
+	if err != nil {
+		return err
+	}
+
+	p, err := update.Apply(current)
+
+	if err != nil {
+		return err
+	}
+
+	c.limiter.Wait()
+
+	if err := c.ttlockService.SetPassageMode(*cred, l.Lock, p); err != nil {
+		// Entities switched optimistically in Home Assistant go back to the lock configuration
+		c.setPassageMode(l, current)
+		return err
+	}
+
+	c.setPassageMode(l, p)
+
+	return nil
+}
@@ -0,0 +1,57 @@
+package controller
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/nikolai5slo/ttlock2mqtt/mqtt"
+)
+
+// pollLock fetches the lock state and publishes it together with lock availability,
+// it reports whether the state differs from the previous poll
+func (c *Controller) pollLock(lockID int32) (bool, error) {
+	// Nobody would see the state, all locks are polled again on reconnect
+	if !c.mqttConnected.Load() {
+		return false, nil
+	}
+
+	l, cred, ok := c.getIntroducedLock(lockID)
+
+	if !ok {
+		return false, nil
+	}
+
+	if cred == nil {
+		return false, fmt.Errorf("cannot find credentials: %d", l.CredentialsID)
+	}
+
+	c.limiter.Wait()
+
+	status, err := c.ttlockService.GetLockStatus(*cred, l.Lock)
+
+	if err != nil {
+		log.Printf("cannot get lock status [%d]: %s", l.LockId, err)
+	}
+
+	// Lock is reachable only if the status query succeeded
+	if err := c.mqtt.UpdateLockAvailability(l, err == nil); err != nil {
+		log.Printf("failed to update lock availability: %s", err)
+	}
+
+	info := mqtt.LockPollInfo{
+		Account:   cred.Username,
+		LastPoll:  time.Now(),
+		LastError: err,
+	}
+
+	if err := c.mqtt.UpdateLockAttributes(l, info); err != nil {
+		log.Printf("failed to update lock attributes: %s", err)
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	return c.applyStatus(l, status), nil
+}
@@ -0,0 +1,67 @@
+package controller
+
+import (
+	"sync"
+	"time"
+)
+
+// rateLimiter is a token bucket shared by all background TTLock API calls
+type rateLimiter struct {
+	mu       sync.Mutex
+	rate     float64 // tokens per second
+	capacity float64
+	tokens   float64
+	last     time.Time
+}
+
+// newRateLimiter allows perMinute requests per minute, zero or less means unlimited
+func newRateLimiter(perMinute int) *rateLimiter {
+	// Allow bursts of 10s worth of requests
+	burst := float64(perMinute) / 6
+	if burst < 1 {
+		burst = 1
+	}
+
+	return &rateLimiter{
+		rate:     float64(perMinute) / 60,
+		capacity: burst,
+		tokens:   burst,
+		last:     time.Now(),
+	}
+}
+
+// Wait blocks until a request fits into the budget
+func (r *rateLimiter) Wait() {
+	if r == nil || r.rate <= 0 {
+		return
+	}
+
+	for {
+		wait := r.reserve(time.Now())
+
+		if wait == 0 {
+			return
+		}
+
+		time.Sleep(wait)
+	}
+}
+
+// reserve takes a token if one is available at now, otherwise it returns how long to wait for one
+func (r *rateLimiter) reserve(now time.Time) time.Duration {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.tokens += now.Sub(r.last).Seconds() * r.rate
+	if r.tokens > r.capacity {
+		r.tokens = r.capacity
+	}
+	r.last = now
+
+	if r.tokens >= 1 {
+		r.tokens--
+		return 0
+	}
+
+	return time.Duration((1 - r.tokens) / r.rate * float64(time.Second))
+}
@@ -0,0 +1,53 @@
+package controller
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRateLimiterReserve(t *testing.T) {
+	tests := []struct {
+		name      string
+		perMinute int
+		// Offsets from the start at which requests are made
+		requests []time.Duration
+		// Expected wait of the last request
+		want time.Duration
+	}{
+		{"first request", 60, []time.Duration{0}, 0},
+		{"within burst", 60, []time.Duration{0, 0, 0, 0, 0, 0, 0, 0, 0, 0}, 0},
+		{"burst used up", 60, []time.Duration{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}, time.Second},
+		{"refilled after a second", 60, []time.Duration{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, time.Second}, 0},
+		{"half refilled", 60, []time.Duration{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 500 * time.Millisecond}, 500 * time.Millisecond},
+		{"burst of at least one", 1, []time.Duration{0}, 0},
+		{"slow rate used up", 1, []time.Duration{0, 0}, time.Minute},
+		{"refill capped at burst", 6, []time.Duration{time.Hour, time.Hour}, 10 * time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start := time.Now()
+			r := newRateLimiter(tt.perMinute)
+			r.last = start
+
+			var got time.Duration
+			for _, offset := range tt.requests {
+				got = r.reserve(start.Add(offset))
+			}
+
+			if diff := got - tt.want; diff > time.Millisecond || diff < -time.Millisecond {
+				t.Errorf("reserve() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRateLimiterUnlimited(t *testing.T) {
+	var nilLimiter *rateLimiter
+	nilLimiter.Wait()
+
+	r := newRateLimiter(0)
+	for i := 0; i < 100; i++ {
+		r.Wait()
+	}
+}
@@ -0,0 +1,235 @@
+package controller
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/nikolai5slo/ttlock2mqtt/locks"
+	"github.com/nikolai5slo/ttlock2mqtt/mqtt"
+	"github.com/nikolai5slo/ttlock2mqtt/ttlock"
+)
+
+// reconcile brings introduced locks in line with the managed locks from storage
+func (c *Controller) reconcile(mLocks locks.LockList) error {
+	// Retire locks that are no longer managed
+	for _, l := range c.getIntroducedLocks().Diff(mLocks) {
+		c.retireLock(l)
+	}
+
+	// Locks that start or stop sharing an alias move between alias and lock id topics
+	collisions := c.mqtt.AliasCollisions(mLocks)
+	released := map[int32]bool{}
+
+	for _, l := range c.getIntroducedLocks() {
+		if c.mqtt.AliasCollides(l.LockId) == collisions[l.LockId] {
+			continue
+		}
+
+		if err := c.mqtt.ReleaseTopics(l); err != nil {
+			log.Printf("failed to release topics of lock [%d]: %s", l.LockId, err)
+		}
+
+		released[l.LockId] = true
+	}
+
+	c.mqtt.SetAliasCollisions(collisions)
+
+	// Update locks that were changed in storage
+	for _, l := range mLocks {
+		introducedLocks := c.getIntroducedLocks()
+		idx := introducedLocks.Find(l.LockId)
+
+		if idx < 0 || (!released[l.LockId] && !lockChanged(introducedLocks[idx], l)) {
+			continue
+		}
+
+		if err := c.updateLock(introducedLocks[idx], l, released[l.LockId]); err != nil {
+			log.Printf("failed to update lock [%d]: %s", l.LockId, err)
+		}
+	}
+
+	// Introduce new locks
+	for _, l := range mLocks.Diff(c.getIntroducedLocks()) {
+		if err := c.introduceLock(l); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *Controller) introduceLock(l locks.ManagedLock) error {
+	err := c.mqtt.IntroduceLock(l)
+	if err != nil {
+		return fmt.Errorf("was not able to update the %d lock on mqtt: %w", l.LockId, err)
+	}
+
+	// Monitor lock commands
+	if err := c.mqtt.MqttLockCommandCallback(l, c.commandCallback(l.LockId)); err != nil {
+		log.Printf("Failed to monitor lock: %s", err)
+		return nil
+	}
+
+	if err := c.mqtt.MqttPassageModeCallback(l, c.passageModeCallback(l.LockId)); err != nil {
+		log.Printf("Failed to monitor lock passage mode: %s", err)
+	}
+
+	log.Printf("introduced new lock: %d", l.LockId)
+	c.locksMu.Lock()
+	c.introducedLocks = c.introducedLocks.Add(l)
+	c.locksMu.Unlock()
+
+	c.scheduler.Add(l.LockId)
+
+	// Only records after introduction are published as events
+	c.recordsMu.Lock()
+	if _, ok := c.lastRecords[l.LockId]; !ok {
+		c.lastRecords[l.LockId] = newRecordMark(time.Now().UnixMilli())
+	}
+	c.recordsMu.Unlock()
+
+	// Publish battery from stored snapshot until details get refreshed
+	if l.ElectricQuantity != nil {
+		if err := c.mqtt.UpdateLockBattery(l, *l.ElectricQuantity); err != nil {
+			log.Printf("failed to update lock battery: %s", err)
+		}
+	}
+
+	// Locks introduced on start get their passage mode with the first details refresh
+	if _, cred, _ := c.getIntroducedLock(l.LockId); cred != nil && !c.lastDetailsRefresh.IsZero() &&
+		ttlock.SupportsFeature(l.Lock, ttlock.FeaturePassageMode) {
+		if _, err := c.refreshPassageMode(l, *cred); err != nil {
+			log.Printf("cannot refresh passage mode [%d]: %s", l.LockId, err)
+		}
+	}
+
+	return nil
+}
+
+// updateLock applies storage changes, e.g. a new alias or credentials, to an introduced lock.
+// Released is set when the topics of the lock were already released.
+func (c *Controller) updateLock(old locks.ManagedLock, l locks.ManagedLock, released bool) error {
+	// Keep what was learned from lock details, storage only has the snapshot from adding the lock
+	l.ModelNum = old.ModelNum
+	l.FirmwareRevision = old.FirmwareRevision
+	l.HardwareRevision = old.HardwareRevision
+	if old.ElectricQuantity != nil {
+		l.ElectricQuantity = old.ElectricQuantity
+	}
+
+	// Topics are keyed by alias, move the lock to the new topics
+	if released || !c.mqtt.SameTopics(old, l) {
+		if !released {
+			if err := c.mqtt.ReleaseTopics(old); err != nil {
+				return err
+			}
+		}
+
+		if err := c.mqtt.MqttLockCommandCallback(l, c.commandCallback(l.LockId)); err != nil {
+			return err
+		}
+
+		if err := c.mqtt.MqttPassageModeCallback(l, c.passageModeCallback(l.LockId)); err != nil {
+			return err
+		}
+	}
+
+	if err := c.mqtt.IntroduceLock(l); err != nil {
+		return err
+	}
+
+	log.Printf("updated lock: %d", l.LockId)
+
+	c.locksMu.Lock()
+	c.introducedLocks = c.introducedLocks.Add(l)
+	c.locksMu.Unlock()
+
+	if l.ElectricQuantity != nil {
+		if err := c.mqtt.UpdateLockBattery(l, *l.ElectricQuantity); err != nil {
+			log.Printf("failed to update lock battery: %s", err)
+		}
+	}
+
+	if p, ok := c.getPassageMode(l.LockId); ok {
+		if err := c.mqtt.UpdatePassageMode(l, p); err != nil {
+			log.Printf("failed to update passage mode: %s", err)
+		}
+	}
+
+	// Republishes state and attributes
+	c.scheduler.PollNow(l.LockId)
+
+	return nil
+}
+
+func (c *Controller) retireLock(l locks.ManagedLock) {
+	if err := c.mqtt.RetireLock(l); err != nil {
+		log.Printf("failed to retire lock [%d]: %s", l.LockId, err)
+		return
+	}
+
+	log.Printf("retired lock: %d", l.LockId)
+
+	c.scheduler.Remove(l.LockId)
+	c.mqtt.SetLockGateway(l.LockId, 0)
+
+	c.locksMu.Lock()
+	c.introducedLocks = c.introducedLocks.Remove(l.LockId)
+	c.locksMu.Unlock()
+
+	c.recordsMu.Lock()
+	delete(c.lastRecords, l.LockId)
+	c.recordsMu.Unlock()
+
+	c.statesMu.Lock()
+	delete(c.lockStates, l.LockId)
+	delete(c.pending, l.LockId)
+	c.statesMu.Unlock()
+
+	c.passageMu.Lock()
+	delete(c.passageModes, l.LockId)
+	c.passageMu.Unlock()
+}
+
+// commandCallback executes MQTT commands on the lock as it is currently introduced
+func (c *Controller) commandCallback(lockID int32) func(cmd mqtt.LockCommand) {
+	return func(cmd mqtt.LockCommand) {
+		l, _, ok := c.getIntroducedLock(lockID)
+
+		if !ok {
+			return
+		}
+
+		start := time.Now()
+		err := c.executeCommand(l, cmd.Status)
+
+		if err != nil {
+			log.Printf("failed to lock/unlock [%d]: %s", lockID, err)
+		}
+
+		if err := c.mqtt.PublishCommandResult(l, cmd, time.Since(start), err); err != nil {
+			log.Printf("failed to publish command result: %s", err)
+		}
+	}
+}
+
+// lockChanged compares the fields that are kept in storage
+func lockChanged(a locks.ManagedLock, b locks.ManagedLock) bool {
+	return a.CredentialsID != b.CredentialsID ||
+		a.LockAlias != b.LockAlias ||
+		a.LockName != b.LockName ||
+		stringValue(a.LockMac) != stringValue(b.LockMac) ||
+		stringValue(a.GroupName) != stringValue(b.GroupName) ||
+		int32Value(a.GroupId) != int32Value(b.GroupId) ||
+		int32Value(a.HasGateway) != int32Value(b.HasGateway) ||
+		a.Missing != b.Missing
+}
+
+func int32Value(i *int32) int32 {
+	if i == nil {
+		return 0
+	}
+
+	return *i
+}
@@ -0,0 +1,180 @@
+package controller
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/nikolai5slo/ttlock2mqtt/credentials"
+	"github.com/nikolai5slo/ttlock2mqtt/locks"
+	"github.com/nikolai5slo/ttlock2mqtt/ttlock"
+)
+
+const recordsPageSize = 100
+
+// recordMark is the high-water mark of published records, records sharing the lock date of the
+// last published one are told apart by their key
+type recordMark struct {
+	date int64
+	seen map[string]bool
+}
+
+func newRecordMark(date int64) *recordMark {
+	return &recordMark{date: date, seen: map[string]bool{}}
+}
+
+// Callback records have no record id, records are identified by content so polls do not repeat them
+func recordKey(r ttlock.Record) string {
+	return fmt.Sprintf("%d/%d/%d/%s/%s", r.RecordType, r.RecordTypeFromLock, r.Success, r.Username, r.KeyboardPwd)
+}
+
+// isNew reports whether the record was not published yet
+func (m *recordMark) isNew(r ttlock.Record) bool {
+	return r.LockDate > m.date || (r.LockDate == m.date && !m.seen[recordKey(r)])
+}
+
+// advance moves the mark to the published record
+func (m *recordMark) advance(r ttlock.Record) {
+	if r.LockDate > m.date {
+		m.date = r.LockDate
+		m.seen = map[string]bool{}
+	}
+
+	m.seen[recordKey(r)] = true
+}
+
+// HandleRecords applies lock records pushed by the TTLock cloud callback
+func (c *Controller) HandleRecords(lockID int32, records []ttlock.Record) error {
+	introducedLocks := c.getIntroducedLocks()
+
+	idx := introducedLocks.Find(lockID)
+
+	if idx < 0 {
+		return fmt.Errorf("lock %d is not managed", lockID)
+	}
+
+	l := introducedLocks[idx]
+
+	// Apply records in the order they happened on the lock
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].LockDate < records[j].LockDate
+	})
+
+	status := ttlock.Unknown
+	var battery *int32
+	var lockRecords []ttlock.Record
+
+	for _, r := range records {
+		if r.LockId != 0 && r.LockId != lockID {
+			log.Printf("ignoring record for lock %d in callback for lock %d", r.LockId, lockID)
+			continue
+		}
+
+		lockRecords = append(lockRecords, r)
+
+		if s := r.LockStatus(); s != ttlock.Unknown {
+			status = s
+		}
+
+		if r.ElectricQuantity != nil {
+			battery = r.ElectricQuantity
+		}
+	}
+
+	// Lock is active, follow up on its state
+	c.scheduler.Boost(lockID)
+
+	// Lock is reachable if it is reporting events
+	if err := c.mqtt.UpdateLockAvailability(l, true); err != nil {
+		return fmt.Errorf("failed to update lock availability: %w", err)
+	}
+
+	if status != ttlock.Unknown {
+		c.applyStatus(l, status)
+	}
+
+	if battery != nil {
+		if err := c.mqtt.UpdateLockBattery(l, *battery); err != nil {
+			return fmt.Errorf("failed to update lock battery: %w", err)
+		}
+	}
+
+	c.publishRecords(l, lockRecords)
+
+	return nil
+}
+
+// refreshRecords polls the record history of every lock and publishes new records as events
+func (c *Controller) refreshRecords(creds credentials.CredentialsList) {
+	for _, l := range c.getIntroducedLocks() {
+		cred := creds.Get(l.CredentialsID)
+
+		if cred == nil {
+			log.Printf("cannot find credentials: %d", l.CredentialsID)
+			continue
+		}
+
+		c.recordsMu.Lock()
+		mark, ok := c.lastRecords[l.LockId]
+		var since time.Time
+		if ok {
+			since = time.UnixMilli(mark.date)
+		}
+		c.recordsMu.Unlock()
+
+		if !ok {
+			continue
+		}
+
+		var records []ttlock.Record
+
+		for pageNo := int32(1); ; pageNo++ {
+			c.limiter.Wait()
+
+			page, err := c.ttlockService.GetLockRecords(*cred, l.Lock, since, pageNo, recordsPageSize)
+
+			if err != nil {
+				log.Printf("cannot get lock records [%d]: %s", l.LockId, err)
+				break
+			}
+
+			records = append(records, page.List...)
+
+			if pageNo >= page.Pages || len(page.List) == 0 {
+				break
+			}
+		}
+
+		c.publishRecords(l, records)
+	}
+}
+
+// publishRecords publishes records newer than the last seen one and advances the marker
+func (c *Controller) publishRecords(l locks.ManagedLock, records []ttlock.Record) {
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].LockDate < records[j].LockDate
+	})
+
+	c.recordsMu.Lock()
+	defer c.recordsMu.Unlock()
+
+	mark, ok := c.lastRecords[l.LockId]
+
+	if !ok {
+		return
+	}
+
+	for _, r := range records {
+		if !mark.isNew(r) {
+			continue
+		}
+
+		if err := c.mqtt.PublishLockEvent(l, r); err != nil {
+			log.Printf("failed to publish lock event [%d]: %s", l.LockId, err)
+			return
+		}
+
+		mark.advance(r)
+	}
+}
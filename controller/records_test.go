@@ -0,0 +1,45 @@
+package controller
+
+import (
+	"testing"
+
+	"github.com/nikolai5slo/ttlock2mqtt/ttlock"
+)
+
+func TestRecordMark(t *testing.T) {
+	unlock := ttlock.Record{RecordType: 7, Success: 1, Username: "alice", LockDate: 1000}
+	unlockOther := ttlock.Record{RecordType: 7, Success: 1, Username: "bob", LockDate: 1000}
+	lock := ttlock.Record{RecordType: 47, Success: 1, LockDate: 1000}
+	later := ttlock.Record{RecordType: 47, Success: 1, LockDate: 2000}
+	earlier := ttlock.Record{RecordType: 7, Success: 1, Username: "alice", LockDate: 500}
+
+	tests := []struct {
+		name      string
+		published []ttlock.Record
+		record    ttlock.Record
+		want      bool
+	}{
+		{"newer than mark", nil, later, true},
+		{"older than mark", nil, earlier, false},
+		{"at mark, nothing published", nil, unlock, true},
+		{"already published", []ttlock.Record{unlock}, unlock, false},
+		{"same date, other user", []ttlock.Record{unlock}, unlockOther, true},
+		{"same date, other type", []ttlock.Record{unlock, unlockOther}, lock, true},
+		{"same date after newer record", []ttlock.Record{unlock, later}, unlockOther, false},
+		{"newer record published", []ttlock.Record{later}, later, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newRecordMark(1000)
+
+			for _, r := range tt.published {
+				m.advance(r)
+			}
+
+			if got := m.isNew(tt.record); got != tt.want {
+				t.Errorf("isNew() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
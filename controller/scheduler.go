@@ -0,0 +1,249 @@
+package controller
+
+import (
+	"log"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// pollIntervals controls how often locks get polled
+type pollIntervals struct {
+	normal     time.Duration
+	jitter     float64
+	maxBackoff time.Duration
+
+	// Locks are polled at the fast interval for a window after commands or changes
+	fast       time.Duration
+	fastWindow time.Duration
+
+	// Locks without changes for idleAfter are polled at the idle interval
+	idle      time.Duration
+	idleAfter time.Duration
+}
+
+// pollScheduler polls every lock on its own timer with a bounded number of concurrent polls
+type pollScheduler struct {
+	intervals pollIntervals
+	workers   int
+	poll      func(lockID int32) (changed bool, err error)
+
+	mu      sync.Mutex
+	entries map[int32]*pollEntry
+	wake    chan struct{}
+	stop    chan struct{}
+}
+
+type pollEntry struct {
+	next       time.Time
+	failures   int
+	inFlight   bool
+	fastUntil  time.Time
+	lastChange time.Time
+}
+
+func newPollScheduler(intervals pollIntervals, workers int, poll func(int32) (bool, error)) *pollScheduler {
+	if workers < 1 {
+		workers = 1
+	}
+
+	if intervals.maxBackoff < intervals.normal {
+		intervals.maxBackoff = intervals.normal
+	}
+
+	return &pollScheduler{
+		intervals: intervals,
+		workers:   workers,
+		poll:      poll,
+		entries:   map[int32]*pollEntry{},
+		wake:      make(chan struct{}, 1),
+		stop:      make(chan struct{}),
+	}
+}
+
+// Add schedules lock for an immediate poll
+func (s *pollScheduler) Add(lockID int32) {
+	s.mu.Lock()
+	if _, ok := s.entries[lockID]; !ok {
+		s.entries[lockID] = &pollEntry{next: time.Now(), lastChange: time.Now()}
+	}
+	s.mu.Unlock()
+
+	s.notify()
+}
+
+func (s *pollScheduler) Remove(lockID int32) {
+	s.mu.Lock()
+	delete(s.entries, lockID)
+	s.mu.Unlock()
+}
+
+// PollNow moves the next poll of the lock to now
+func (s *pollScheduler) PollNow(lockID int32) {
+	s.mu.Lock()
+	if e, ok := s.entries[lockID]; ok {
+		e.next = time.Now()
+	}
+	s.mu.Unlock()
+
+	s.notify()
+}
+
+// Boost polls the lock now and then at the fast interval for the fast poll window
+func (s *pollScheduler) Boost(lockID int32) {
+	if s.intervals.fast <= 0 || s.intervals.fastWindow <= 0 {
+		s.PollNow(lockID)
+		return
+	}
+
+	s.mu.Lock()
+	if e, ok := s.entries[lockID]; ok {
+		e.next = time.Now()
+		e.fastUntil = time.Now().Add(s.intervals.fastWindow)
+	}
+	s.mu.Unlock()
+
+	s.notify()
+}
+
+func (s *pollScheduler) Run() {
+	jobs := make(chan int32)
+
+	for i := 0; i < s.workers; i++ {
+		go func() {
+			for lockID := range jobs {
+				changed, err := s.poll(lockID)
+				s.done(lockID, changed, err)
+			}
+		}()
+	}
+
+	defer close(jobs)
+
+	for {
+		due, wait := s.due()
+
+		for _, lockID := range due {
+			select {
+			case jobs <- lockID:
+			case <-s.stop:
+				return
+			}
+		}
+
+		if len(due) > 0 {
+			continue
+		}
+
+		timer := time.NewTimer(wait)
+
+		select {
+		case <-timer.C:
+		case <-s.wake:
+			timer.Stop()
+		case <-s.stop:
+			timer.Stop()
+			return
+		}
+	}
+}
+
+func (s *pollScheduler) Stop() {
+	close(s.stop)
+}
+
+// due marks due locks as in flight and returns them, with the time until the next one is due
+func (s *pollScheduler) due() ([]int32, time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	wait := s.intervals.normal
+	var due []int32
+
+	for lockID, e := range s.entries {
+		if e.inFlight {
+			continue
+		}
+
+		if !e.next.After(now) {
+			e.inFlight = true
+			due = append(due, lockID)
+		} else if d := e.next.Sub(now); d < wait {
+			wait = d
+		}
+	}
+
+	return due, wait
+}
+
+func (s *pollScheduler) done(lockID int32, changed bool, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, ok := s.entries[lockID]
+
+	// Removed while polling
+	if !ok {
+		return
+	}
+
+	e.inFlight = false
+	now := time.Now()
+
+	if changed {
+		e.lastChange = now
+		if s.intervals.fast > 0 {
+			e.fastUntil = now.Add(s.intervals.fastWindow)
+		}
+	}
+
+	delay := s.intervals.normal
+
+	if err != nil {
+		e.failures++
+
+		// Exponential backoff, capped
+		for i := 0; i < e.failures && delay < s.intervals.maxBackoff; i++ {
+			delay *= 2
+		}
+		if delay > s.intervals.maxBackoff {
+			delay = s.intervals.maxBackoff
+		}
+
+		log.Printf("poll of lock %d failed %d times, next try in %s", lockID, e.failures, delay)
+	} else {
+		e.failures = 0
+		delay = s.interval(e, now)
+	}
+
+	e.next = now.Add(s.withJitter(delay))
+}
+
+// interval picks the poll interval of a healthy lock based on its recent activity
+func (s *pollScheduler) interval(e *pollEntry, now time.Time) time.Duration {
+	if now.Before(e.fastUntil) {
+		return s.intervals.fast
+	}
+
+	if s.intervals.idle > 0 && s.intervals.idleAfter > 0 && now.Sub(e.lastChange) >= s.intervals.idleAfter {
+		return s.intervals.idle
+	}
+
+	return s.intervals.normal
+}
+
+func (s *pollScheduler) withJitter(d time.Duration) time.Duration {
+	if s.intervals.jitter <= 0 {
+		return d
+	}
+
+	return d + time.Duration((rand.Float64()*2-1)*s.intervals.jitter*float64(d))
+}
+
+func (s *pollScheduler) notify() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
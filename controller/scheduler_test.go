@@ -0,0 +1,43 @@
+package controller
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestPollSchedulerBackoff(t *testing.T) {
+	intervals := pollIntervals{normal: time.Minute, maxBackoff: 10 * time.Minute}
+	errPoll := errors.New("poll failed")
+
+	tests := []struct {
+		name     string
+		failures int
+		err      error
+		want     time.Duration
+	}{
+		{"success", 0, nil, time.Minute},
+		{"success resets failures", 5, nil, time.Minute},
+		{"first failure", 0, errPoll, 2 * time.Minute},
+		{"second failure", 1, errPoll, 4 * time.Minute},
+		{"third failure", 2, errPoll, 8 * time.Minute},
+		{"capped", 3, errPoll, 10 * time.Minute},
+		{"many failures stay capped", 50, errPoll, 10 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newPollScheduler(intervals, 1, nil)
+			s.Add(1)
+			s.entries[1].failures = tt.failures
+
+			before := time.Now()
+			s.done(1, false, tt.err)
+			got := s.entries[1].next.Sub(before)
+
+			if got < tt.want || got > tt.want+time.Second {
+				t.Errorf("next poll in %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
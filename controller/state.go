@@ -0,0 +1,96 @@
+package controller
+
+import (
+	"log"
+	"time"
+
+	"github.com/nikolai5slo/ttlock2mqtt/locks"
+	"github.com/nikolai5slo/ttlock2mqtt/ttlock"
+)
+
+// pendingCommand is a lock command waiting for the lock to confirm the target state
+type pendingCommand struct {
+	target   ttlock.LockStatus
+	deadline time.Time
+
+	// Lock did not reach the target in time, it stays jammed while it reports the observed state
+	jammed   bool
+	observed ttlock.LockStatus
+}
+
+func (p pendingCommand) transitional() ttlock.LockStatus {
+	if p.target == ttlock.Locked {
+		return ttlock.Locking
+	}
+
+	return ttlock.Unlocking
+}
+
+// startCommand marks the lock as locking or unlocking until the target state is confirmed
+func (c *Controller) startCommand(l locks.ManagedLock, target ttlock.LockStatus) {
+	p := pendingCommand{
+		target:   target,
+		deadline: time.Now().Add(c.commandTimeout),
+	}
+
+	c.statesMu.Lock()
+	c.pending[l.LockId] = p
+	c.statesMu.Unlock()
+
+	if err := c.mqtt.UpdateLockStatus(l, p.transitional()); err != nil {
+		log.Printf("failed to update lock status: %s", err)
+	}
+}
+
+// failCommand restores the last known state after the command was rejected
+func (c *Controller) failCommand(l locks.ManagedLock) {
+	c.statesMu.Lock()
+	delete(c.pending, l.LockId)
+	status, ok := c.lockStates[l.LockId]
+	c.statesMu.Unlock()
+
+	if !ok {
+		status = ttlock.Unknown
+	}
+
+	if err := c.mqtt.UpdateLockStatus(l, status); err != nil {
+		log.Printf("failed to update lock status: %s", err)
+	}
+}
+
+// applyStatus publishes the state reported by the lock, taking pending commands into account,
+// and reports whether it differs from the previously reported state
+func (c *Controller) applyStatus(l locks.ManagedLock, status ttlock.LockStatus) bool {
+	c.statesMu.Lock()
+
+	previous, known := c.lockStates[l.LockId]
+	c.lockStates[l.LockId] = status
+
+	publish := status
+
+	if p, ok := c.pending[l.LockId]; ok {
+		switch {
+		case p.jammed && status == p.observed:
+			publish = ttlock.Jammed
+		case p.jammed || status == p.target:
+			delete(c.pending, l.LockId)
+		case time.Now().After(p.deadline):
+			log.Printf("lock %d did not reach the commanded state in %s", l.LockId, c.commandTimeout)
+
+			p.jammed = true
+			p.observed = status
+			c.pending[l.LockId] = p
+			publish = ttlock.Jammed
+		default:
+			publish = p.transitional()
+		}
+	}
+
+	c.statesMu.Unlock()
+
+	if err := c.mqtt.UpdateLockStatus(l, publish); err != nil {
+		log.Printf("failed to update lock status: %s", err)
+	}
+
+	return known && previous != status
+}
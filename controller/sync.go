@@ -0,0 +1,118 @@
+package controller
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/nikolai5slo/ttlock2mqtt/credentials"
+	"github.com/nikolai5slo/ttlock2mqtt/locks"
+	"github.com/nikolai5slo/ttlock2mqtt/ttlock"
+)
+
+// syncMetadata refreshes stored locks with the lock list of their accounts, locks no longer on
+// the account are flagged as missing. It returns the managed locks as saved.
+func (c *Controller) syncMetadata(mLocks locks.LockList, creds credentials.CredentialsList) (locks.LockList, error) {
+	updates := map[int32]locks.ManagedLock{}
+	fetched := map[int32][]ttlock.Lock{}
+	failed := map[int32]bool{}
+
+	for _, ml := range mLocks {
+		if failed[ml.CredentialsID] {
+			continue
+		}
+
+		accountLocks, ok := fetched[ml.CredentialsID]
+
+		if !ok {
+			cred := creds.Get(ml.CredentialsID)
+
+			if cred == nil {
+				log.Printf("cannot find credentials: %d", ml.CredentialsID)
+				failed[ml.CredentialsID] = true
+				continue
+			}
+
+			c.limiter.Wait()
+
+			var err error
+			accountLocks, err = c.ttlockService.GetLocks(*cred, ttlock.LockFilter{})
+
+			if err != nil {
+				log.Printf("cannot list locks of %s: %s", cred.Username, err)
+				failed[ml.CredentialsID] = true
+				continue
+			}
+
+			fetched[ml.CredentialsID] = accountLocks
+		}
+
+		synced := syncLock(ml, accountLocks)
+
+		if synced.Missing && !ml.Missing {
+			log.Printf("lock %d is not on its account anymore", ml.LockId)
+		}
+
+		if lockChanged(ml, synced) || stringValue(ml.FeatureValue) != stringValue(synced.FeatureValue) ||
+			int32Value(ml.ElectricQuantity) != int32Value(synced.ElectricQuantity) {
+			updates[ml.LockId] = synced
+		}
+	}
+
+	if len(updates) == 0 {
+		return mLocks, nil
+	}
+
+	// Reload, locks may have been changed in the meantime
+	current := locks.LockList{}
+
+	if err := c.lockStorage.Load(&current); err != nil {
+		return mLocks, fmt.Errorf("cannot load locks: %w", err)
+	}
+
+	for i, ml := range current {
+		if u, ok := updates[ml.LockId]; ok && u.CredentialsID == ml.CredentialsID {
+			current[i] = u
+		}
+	}
+
+	if err := c.lockStorage.Save(current); err != nil {
+		return mLocks, fmt.Errorf("cannot save locks: %w", err)
+	}
+
+	log.Printf("synced metadata of %d locks", len(updates))
+
+	return current, nil
+}
+
+// syncLock takes the account version of the lock, keeping values the lock list does not include
+func syncLock(ml locks.ManagedLock, accountLocks []ttlock.Lock) locks.ManagedLock {
+	for _, l := range accountLocks {
+		if l.LockId != ml.LockId {
+			continue
+		}
+
+		synced := locks.ManagedLock{Lock: l, CredentialsID: ml.CredentialsID}
+
+		if synced.ElectricQuantity == nil {
+			synced.ElectricQuantity = ml.ElectricQuantity
+		}
+		if synced.ModelNum == nil {
+			synced.ModelNum = ml.ModelNum
+		}
+		if synced.FirmwareRevision == nil {
+			synced.FirmwareRevision = ml.FirmwareRevision
+		}
+		if synced.HardwareRevision == nil {
+			synced.HardwareRevision = ml.HardwareRevision
+		}
+		if synced.LockData == nil {
+			synced.LockData = ml.LockData
+		}
+
+		return synced
+	}
+
+	ml.Missing = true
+
+	return ml
+}
@@ -0,0 +1,256 @@
+package credentials
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"strings"
+)
+
+const encryptedPrefix = "enc:v1:"
+
+type encryptionKey struct {
+	id   string
+	aead cipher.AEAD
+}
+
+// EncryptedStore encrypts access and refresh tokens with AES-GCM before they reach the wrapped storage
+type EncryptedStore struct {
+	store   Storage
+	primary encryptionKey
+	keys    map[string]encryptionKey
+}
+
+type EncryptedStoreConf func(*EncryptedStore) error
+
+func NewEncryptedStore(store Storage, cfg ...EncryptedStoreConf) (*EncryptedStore, error) {
+	s := &EncryptedStore{
+		store: store,
+		keys:  map[string]encryptionKey{},
+	}
+
+	for _, c := range cfg {
+		if err := c(s); err != nil {
+			return s, fmt.Errorf("encrypted store configuration failed: %w", err)
+		}
+	}
+
+	if s.primary.aead == nil {
+		return s, fmt.Errorf("encryption key is not configured")
+	}
+
+	return s, nil
+}
+
+// WithEncryptionKey sets the key used for encryption, key is 32 bytes encoded as base64 or hex
+func WithEncryptionKey(key string) EncryptedStoreConf {
+	return func(s *EncryptedStore) error {
+		k, err := parseKey(key)
+
+		if err != nil {
+			return err
+		}
+
+		s.primary = k
+		s.keys[k.id] = k
+		return nil
+	}
+}
+
+// WithDecryptionKeys adds previous keys, values encrypted with them are re-encrypted with the current key
+func WithDecryptionKeys(keys ...string) EncryptedStoreConf {
+	return func(s *EncryptedStore) error {
+		for _, key := range keys {
+			if key == "" {
+				continue
+			}
+
+			k, err := parseKey(key)
+
+			if err != nil {
+				return err
+			}
+
+			s.keys[k.id] = k
+		}
+		return nil
+	}
+}
+
+// GenerateKey returns a new random key in the format accepted by WithEncryptionKey
+func GenerateKey() (string, error) {
+	key := make([]byte, 32)
+
+	if _, err := rand.Read(key); err != nil {
+		return "", err
+	}
+
+	return base64.StdEncoding.EncodeToString(key), nil
+}
+
+func (s *EncryptedStore) Save(l CredentialsList) error {
+	encrypted := make(CredentialsList, len(l))
+
+	for i, c := range l {
+		var err error
+
+		if c.AccessToken, err = s.encrypt(c.AccessToken); err != nil {
+			return err
+		}
+
+		if c.RefreshToken, err = s.encrypt(c.RefreshToken); err != nil {
+			return err
+		}
+
+		encrypted[i] = c
+	}
+
+	return s.store.Save(encrypted)
+}
+
+// Load decrypts stored tokens, plaintext or stale encrypted values are migrated to the current key
+func (s *EncryptedStore) Load(l *CredentialsList) error {
+	stored := CredentialsList{}
+
+	if err := s.store.Load(&stored); err != nil {
+		return err
+	}
+
+	migrate := false
+	decrypted := make(CredentialsList, len(stored))
+
+	for i, c := range stored {
+		var err error
+		var current bool
+
+		if c.AccessToken, current, err = s.decrypt(c.AccessToken); err != nil {
+			return fmt.Errorf("cannot decrypt access token of %s: %w", c.Username, err)
+		}
+		migrate = migrate || !current
+
+		if c.RefreshToken, current, err = s.decrypt(c.RefreshToken); err != nil {
+			return fmt.Errorf("cannot decrypt refresh token of %s: %w", c.Username, err)
+		}
+		migrate = migrate || !current
+
+		decrypted[i] = c
+	}
+
+	if migrate {
+		if err := s.Save(decrypted); err != nil {
+			return fmt.Errorf("cannot migrate credentials encryption: %w", err)
+		}
+
+		log.Printf("re-encrypted stored credentials with key %s", s.primary.id)
+	}
+
+	*l = decrypted
+
+	return nil
+}
+
+// Rotate re-encrypts all stored credentials with the current key
+func (s *EncryptedStore) Rotate() error {
+	creds := CredentialsList{}
+
+	if err := s.Load(&creds); err != nil {
+		return err
+	}
+
+	return s.Save(creds)
+}
+
+func (s *EncryptedStore) encrypt(value string) (string, error) {
+	if value == "" {
+		return "", nil
+	}
+
+	nonce := make([]byte, s.primary.aead.NonceSize())
+
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+
+	sealed := s.primary.aead.Seal(nonce, nonce, []byte(value), []byte(s.primary.id))
+
+	return encryptedPrefix + s.primary.id + ":" + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// decrypt returns the plaintext and whether the value was encrypted with the current key
+func (s *EncryptedStore) decrypt(value string) (string, bool, error) {
+	if value == "" {
+		return "", true, nil
+	}
+
+	if !strings.HasPrefix(value, encryptedPrefix) {
+		return value, false, nil
+	}
+
+	parts := strings.SplitN(strings.TrimPrefix(value, encryptedPrefix), ":", 2)
+
+	if len(parts) != 2 {
+		return "", false, fmt.Errorf("malformed encrypted value")
+	}
+
+	k, ok := s.keys[parts[0]]
+
+	if !ok {
+		return "", false, fmt.Errorf("unknown encryption key %s", parts[0])
+	}
+
+	sealed, err := base64.StdEncoding.DecodeString(parts[1])
+
+	if err != nil {
+		return "", false, err
+	}
+
+	if len(sealed) < k.aead.NonceSize() {
+		return "", false, fmt.Errorf("malformed encrypted value")
+	}
+
+	plain, err := k.aead.Open(nil, sealed[:k.aead.NonceSize()], sealed[k.aead.NonceSize():], []byte(k.id))
+
+	if err != nil {
+		return "", false, err
+	}
+
+	return string(plain), k.id == s.primary.id, nil
+}
+
+func parseKey(key string) (encryptionKey, error) {
+	key = strings.TrimSpace(key)
+
+	raw, err := base64.StdEncoding.DecodeString(key)
+
+	if err != nil || len(raw) != 32 {
+		raw, err = hex.DecodeString(key)
+	}
+
+	if err != nil || len(raw) != 32 {
+		return encryptionKey{}, fmt.Errorf("encryption key must be 32 bytes encoded as base64 or hex")
+	}
+
+	block, err := aes.NewCipher(raw)
+
+	if err != nil {
+		return encryptionKey{}, err
+	}
+
+	aead, err := cipher.NewGCM(block)
+
+	if err != nil {
+		return encryptionKey{}, err
+	}
+
+	sum := sha256.Sum256(raw)
+
+	return encryptionKey{
+		id:   hex.EncodeToString(sum[:4]),
+		aead: aead,
+	}, nil
+}
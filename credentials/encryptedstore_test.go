@@ -0,0 +1,182 @@
+package credentials
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+	"testing"
+)
+
+type memoryStore struct {
+	creds CredentialsList
+}
+
+func (m *memoryStore) Save(l CredentialsList) error {
+	m.creds = append(CredentialsList{}, l...)
+	return nil
+}
+
+func (m *memoryStore) Load(l *CredentialsList) error {
+	*l = append(CredentialsList{}, m.creds...)
+	return nil
+}
+
+func mustKey(t *testing.T) string {
+	t.Helper()
+
+	key, err := GenerateKey()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return key
+}
+
+func TestEncryptedStoreRoundTrip(t *testing.T) {
+	tests := []struct {
+		name  string
+		creds CredentialsList
+	}{
+		{"no credentials", CredentialsList{}},
+		{"tokens", CredentialsList{{ID: 1, Username: "a", AccessToken: "access", RefreshToken: "refresh"}}},
+		{"empty tokens", CredentialsList{{ID: 1, Username: "a"}}},
+		{"several accounts", CredentialsList{
+			{ID: 1, Username: "a", AccessToken: "access-a", RefreshToken: "refresh-a"},
+			{ID: 2, Username: "b", AccessToken: "access-b", RefreshToken: "refresh-b", NeedsLogin: true},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backend := &memoryStore{}
+			s, err := NewEncryptedStore(backend, WithEncryptionKey(mustKey(t)))
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if err := s.Save(tt.creds); err != nil {
+				t.Fatal(err)
+			}
+
+			for i, c := range backend.creds {
+				if c.AccessToken != "" && (c.AccessToken == tt.creds[i].AccessToken || !strings.HasPrefix(c.AccessToken, encryptedPrefix)) {
+					t.Errorf("access token stored in plaintext: %q", c.AccessToken)
+				}
+				if c.RefreshToken != "" && (c.RefreshToken == tt.creds[i].RefreshToken || !strings.HasPrefix(c.RefreshToken, encryptedPrefix)) {
+					t.Errorf("refresh token stored in plaintext: %q", c.RefreshToken)
+				}
+			}
+
+			loaded := CredentialsList{}
+			if err := s.Load(&loaded); err != nil {
+				t.Fatal(err)
+			}
+
+			if len(loaded) != len(tt.creds) {
+				t.Fatalf("loaded %d credentials, want %d", len(loaded), len(tt.creds))
+			}
+
+			for i := range loaded {
+				if loaded[i] != tt.creds[i] {
+					t.Errorf("loaded %+v, want %+v", loaded[i], tt.creds[i])
+				}
+			}
+		})
+	}
+}
+
+func TestEncryptedStoreMigration(t *testing.T) {
+	oldKey := mustKey(t)
+	newKey := mustKey(t)
+	plain := CredentialsList{{ID: 1, Username: "a", AccessToken: "access", RefreshToken: "refresh"}}
+
+	encryptedWith := func(key string) CredentialsList {
+		backend := &memoryStore{}
+		s, err := NewEncryptedStore(backend, WithEncryptionKey(key))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := s.Save(plain); err != nil {
+			t.Fatal(err)
+		}
+
+		return backend.creds
+	}
+
+	tests := []struct {
+		name    string
+		stored  CredentialsList
+		key     string
+		oldKeys []string
+		wantErr bool
+		migrate bool
+	}{
+		{"plaintext is encrypted", plain, newKey, nil, false, true},
+		{"current key is kept", encryptedWith(newKey), newKey, nil, false, false},
+		{"rotated key is re-encrypted", encryptedWith(oldKey), newKey, []string{oldKey}, false, true},
+		{"unknown key fails", encryptedWith(oldKey), newKey, nil, true, false},
+		{"hex key", encryptedWith(oldKey), newKey, []string{hexKey(t, oldKey)}, false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backend := &memoryStore{creds: append(CredentialsList{}, tt.stored...)}
+			s, err := NewEncryptedStore(backend, WithEncryptionKey(tt.key), WithDecryptionKeys(tt.oldKeys...))
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			loaded := CredentialsList{}
+			err = s.Load(&loaded)
+
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if loaded[0] != plain[0] {
+				t.Errorf("loaded %+v, want %+v", loaded[0], plain[0])
+			}
+
+			migrated := backend.creds[0].AccessToken != tt.stored[0].AccessToken
+			if migrated != tt.migrate {
+				t.Errorf("migrated = %v, want %v", migrated, tt.migrate)
+			}
+
+			// Stored values are readable with the new key alone
+			current, err := NewEncryptedStore(backend, WithEncryptionKey(tt.key))
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if err := current.Load(&loaded); err != nil {
+				t.Errorf("cannot load with the new key: %s", err)
+			}
+		})
+	}
+}
+
+// hexKey re-encodes a base64 key as hex, both encodings are accepted
+func hexKey(t *testing.T, key string) string {
+	t.Helper()
+
+	raw, err := base64.StdEncoding.DecodeString(key)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return hex.EncodeToString(raw)
+}
@@ -0,0 +1,93 @@
+package credentials
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/nikolai5slo/ttlock2mqtt/sqlite"
+)
+
+var migrations = []string{
+	`CREATE TABLE credentials (
+		id INTEGER PRIMARY KEY,
+		username TEXT NOT NULL,
+		refresh_token TEXT NOT NULL,
+		access_token TEXT NOT NULL,
+		expires_at INTEGER NOT NULL,
+		needs_login INTEGER NOT NULL DEFAULT 0,
+		position INTEGER NOT NULL
+	)`,
+}
+
+type SqliteStore struct {
+	db *sql.DB
+}
+
+func NewSqliteStore(db *sql.DB) (*SqliteStore, error) {
+	if err := sqlite.Migrate(db, "credentials", migrations); err != nil {
+		return nil, err
+	}
+
+	return &SqliteStore{
+		db: db,
+	}, nil
+}
+
+func (s *SqliteStore) Save(l CredentialsList) error {
+	tx, err := s.db.Begin()
+
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	if _, err := tx.Exec("DELETE FROM credentials"); err != nil {
+		return err
+	}
+
+	for i, c := range l {
+		_, err = tx.Exec(
+			"INSERT INTO credentials (id, username, refresh_token, access_token, expires_at, needs_login, position) VALUES (?, ?, ?, ?, ?, ?, ?)",
+			c.ID, c.Username, c.RefreshToken, c.AccessToken, c.ExpiresAt.Unix(), c.NeedsLogin, i,
+		)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (s *SqliteStore) Load(l *CredentialsList) error {
+	rows, err := s.db.Query("SELECT id, username, refresh_token, access_token, expires_at, needs_login FROM credentials ORDER BY position")
+
+	if err != nil {
+		return err
+	}
+
+	defer rows.Close()
+
+	list := CredentialsList{}
+
+	for rows.Next() {
+		var expiresAt int64
+		c := Credentials{}
+
+		if err := rows.Scan(&c.ID, &c.Username, &c.RefreshToken, &c.AccessToken, &expiresAt, &c.NeedsLogin); err != nil {
+			return err
+		}
+
+		c.ExpiresAt = time.Unix(expiresAt, 0)
+		list = append(list, c)
+	}
+
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	*l = list
+
+	return nil
+}
@@ -40,6 +40,24 @@ func (l CredentialsList) Add(creds ...Credentials) CredentialsList {
 	return nl
 }
 
+func (l CredentialsList) Remove(IDs ...int32) CredentialsList {
+	nl := CredentialsList{}
+	for _, c := range l {
+		removed := false
+		for _, ID := range IDs {
+			if c.ID == ID {
+				removed = true
+				break
+			}
+		}
+
+		if !removed {
+			nl = append(nl, c)
+		}
+	}
+	return nl
+}
+
 type Storage interface {
 	Save(CredentialsList) error
 	Load(*CredentialsList) error
@@ -0,0 +1,190 @@
+package credentials
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/nikolai5slo/ttlock2mqtt/ttlock"
+)
+
+var ErrNeedsLogin = errors.New("credentials need to login again")
+
+// Refresher exchanges a refresh token for new credentials
+type Refresher interface {
+	RefreshToken(cred Credentials) (Credentials, error)
+}
+
+// TokenManager refreshes access tokens ahead of expiry and persists refreshed credentials
+type TokenManager struct {
+	storage   Storage
+	refresher Refresher
+	margin    time.Duration
+
+	mu    sync.Mutex
+	cache map[int32]Credentials
+	locks map[int32]*sync.Mutex
+}
+
+type TokenManagerConf func(*TokenManager) error
+
+func NewTokenManager(cfg ...TokenManagerConf) (*TokenManager, error) {
+	tm := &TokenManager{
+		margin: 24 * time.Hour,
+		cache:  map[int32]Credentials{},
+		locks:  map[int32]*sync.Mutex{},
+	}
+
+	for _, c := range cfg {
+		if err := c(tm); err != nil {
+			return tm, fmt.Errorf("token manager configuration failed: %w", err)
+		}
+	}
+
+	return tm, nil
+}
+
+func WithTokenStorage(s Storage) TokenManagerConf {
+	return func(tm *TokenManager) error {
+		tm.storage = s
+		return nil
+	}
+}
+
+// WithRefreshMargin sets how long before expiry tokens get refreshed
+func WithRefreshMargin(d time.Duration) TokenManagerConf {
+	return func(tm *TokenManager) error {
+		tm.margin = d
+		return nil
+	}
+}
+
+// SetRefresher sets the refresher, it is set after construction as the refresher usually depends on the manager
+func (tm *TokenManager) SetRefresher(r Refresher) {
+	tm.refresher = r
+}
+
+// Token returns the freshest known credentials and refreshes them if they are about to expire
+func (tm *TokenManager) Token(cred Credentials) (Credentials, error) {
+	cred = tm.latest(cred)
+
+	if cred.NeedsLogin {
+		return cred, ErrNeedsLogin
+	}
+
+	if time.Until(cred.ExpiresAt) > tm.margin {
+		return cred, nil
+	}
+
+	return tm.refresh(cred, false)
+}
+
+// Refresh forces a refresh unless another caller already refreshed the same token
+func (tm *TokenManager) Refresh(cred Credentials) (Credentials, error) {
+	return tm.refresh(tm.latest(cred), true)
+}
+
+func (tm *TokenManager) refresh(cred Credentials, force bool) (Credentials, error) {
+	lock := tm.accountLock(cred.ID)
+	lock.Lock()
+	defer lock.Unlock()
+
+	// Someone else may have refreshed while we were waiting
+	latest := tm.latest(cred)
+
+	if latest.NeedsLogin {
+		return latest, ErrNeedsLogin
+	}
+
+	if latest.AccessToken != cred.AccessToken {
+		return latest, nil
+	}
+
+	if !force && time.Until(latest.ExpiresAt) > tm.margin {
+		return latest, nil
+	}
+
+	if tm.refresher == nil {
+		return latest, fmt.Errorf("no token refresher configured")
+	}
+
+	refreshed, err := tm.refresher.RefreshToken(latest)
+
+	if err != nil {
+		var apiErr *ttlock.APIError
+
+		// Rejected by the API, the refresh token is not usable anymore. Other errors are
+		// retried with the next call.
+		if errors.As(err, &apiErr) && apiErr.InvalidGrant() {
+			log.Printf("refresh token of %s rejected, login required: %s", latest.Username, err)
+
+			latest.NeedsLogin = true
+			if saveErr := tm.save(latest); saveErr != nil {
+				log.Printf("failed to save credentials: %s", saveErr)
+			}
+		}
+
+		return latest, err
+	}
+
+	log.Printf("refreshed access token of %s, valid until %s", refreshed.Username, refreshed.ExpiresAt.Format(time.RFC3339))
+
+	if err := tm.save(refreshed); err != nil {
+		return refreshed, fmt.Errorf("failed to save refreshed credentials: %w", err)
+	}
+
+	return refreshed, nil
+}
+
+// latest picks whichever of the given and cached credentials is newer
+func (tm *TokenManager) latest(cred Credentials) Credentials {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+
+	cached, ok := tm.cache[cred.ID]
+
+	if !ok || (cred.ExpiresAt.After(cached.ExpiresAt) && !cred.NeedsLogin) {
+		tm.cache[cred.ID] = cred
+		return cred
+	}
+
+	return cached
+}
+
+func (tm *TokenManager) accountLock(ID int32) *sync.Mutex {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+
+	lock, ok := tm.locks[ID]
+	if !ok {
+		lock = &sync.Mutex{}
+		tm.locks[ID] = lock
+	}
+
+	return lock
+}
+
+func (tm *TokenManager) save(cred Credentials) error {
+	tm.mu.Lock()
+	tm.cache[cred.ID] = cred
+	tm.mu.Unlock()
+
+	if tm.storage == nil {
+		return nil
+	}
+
+	creds := CredentialsList{}
+
+	if err := tm.storage.Load(&creds); err != nil {
+		return err
+	}
+
+	// Account was removed in the meantime
+	if creds.Find(cred.ID) < 0 {
+		return nil
+	}
+
+	return tm.storage.Save(creds.Add(cred))
+}
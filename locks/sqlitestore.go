@@ -0,0 +1,102 @@
+package locks
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/nikolai5slo/ttlock2mqtt/sqlite"
+	"github.com/nikolai5slo/ttlock2mqtt/ttlock"
+)
+
+var migrations = []string{
+	`CREATE TABLE locks (
+		lock_id INTEGER PRIMARY KEY,
+		credentials_id INTEGER NOT NULL,
+		position INTEGER NOT NULL,
+		lock TEXT NOT NULL
+	)`,
+	`ALTER TABLE locks ADD COLUMN missing INTEGER NOT NULL DEFAULT 0`,
+}
+
+type SqliteStore struct {
+	db *sql.DB
+}
+
+func NewSqliteStore(db *sql.DB) (*SqliteStore, error) {
+	if err := sqlite.Migrate(db, "locks", migrations); err != nil {
+		return nil, err
+	}
+
+	return &SqliteStore{
+		db: db,
+	}, nil
+}
+
+func (s *SqliteStore) Save(l LockList) error {
+	tx, err := s.db.Begin()
+
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	if _, err := tx.Exec("DELETE FROM locks"); err != nil {
+		return err
+	}
+
+	for i, ml := range l {
+		data, err := json.Marshal(ml.Lock)
+
+		if err != nil {
+			return fmt.Errorf("cannot serialize lock %d: %w", ml.LockId, err)
+		}
+
+		_, err = tx.Exec("INSERT INTO locks (lock_id, credentials_id, position, lock, missing) VALUES (?, ?, ?, ?, ?)", ml.LockId, ml.CredentialsID, i, string(data), ml.Missing)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (s *SqliteStore) Load(l *LockList) error {
+	rows, err := s.db.Query("SELECT credentials_id, lock, missing FROM locks ORDER BY position")
+
+	if err != nil {
+		return err
+	}
+
+	defer rows.Close()
+
+	list := LockList{}
+
+	for rows.Next() {
+		var data string
+		ml := ManagedLock{}
+
+		if err := rows.Scan(&ml.CredentialsID, &data, &ml.Missing); err != nil {
+			return err
+		}
+
+		lock := ttlock.Lock{}
+
+		if err := json.Unmarshal([]byte(data), &lock); err != nil {
+			return err
+		}
+
+		ml.Lock = lock
+		list = append(list, ml)
+	}
+
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	*l = list
+
+	return nil
+}
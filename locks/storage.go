@@ -5,6 +5,9 @@ import "github.com/nikolai5slo/ttlock2mqtt/ttlock"
 type ManagedLock struct {
 	ttlock.Lock
 	CredentialsID int32
+
+	// Lock was not found on the account during the last metadata sync
+	Missing bool
 }
 
 type LockList []ManagedLock
@@ -35,6 +38,24 @@ func (l LockList) Add(locks ...ManagedLock) LockList {
 	return nl
 }
 
+func (l LockList) Remove(IDs ...int32) LockList {
+	nl := LockList{}
+	for _, c := range l {
+		removed := false
+		for _, ID := range IDs {
+			if c.LockId == ID {
+				removed = true
+				break
+			}
+		}
+
+		if !removed {
+			nl = append(nl, c)
+		}
+	}
+	return nl
+}
+
 // Diff get locks that are present in list and are not in list1
 func (list LockList) Diff(list1 LockList) LockList {
 	newList := LockList{}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/nikolai5slo/ttlock2mqtt/credentials"
+)
+
+func runCommand(cmd string) error {
+	switch cmd {
+	case "generate-key":
+		return generateKey()
+	case "rotate-key":
+		return rotateKey()
+	}
+
+	return fmt.Errorf("unknown command, available commands: generate-key, rotate-key")
+}
+
+// generateKey prints a new key for STORAGE_ENCRYPTION_KEY
+func generateKey() error {
+	key, err := credentials.GenerateKey()
+
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(key)
+
+	return nil
+}
+
+// rotateKey re-encrypts stored credentials with STORAGE_ENCRYPTION_KEY, previous keys are
+// read from STORAGE_ENCRYPTION_OLD_KEYS
+func rotateKey() error {
+	d := &deps{}
+
+	if err := d.buildConfig(); err != nil {
+		return err
+	}
+
+	if err := d.buildStorages(); err != nil {
+		return err
+	}
+
+	if d.db != nil {
+		defer d.db.Close()
+	}
+
+	if d.encryptedStorage == nil {
+		return fmt.Errorf("STORAGE_ENCRYPTION_KEY is not configured")
+	}
+
+	if err := d.encryptedStorage.Rotate(); err != nil {
+		return err
+	}
+
+	log.Printf("credentials re-encrypted")
+
+	return nil
+}
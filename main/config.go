@@ -7,14 +7,45 @@ type Config struct {
 		Address string `env:"SERVER_ADDRESS" env-default:"0.0.0.0:8080"`
 	}
 	TTLock struct {
-		Server          string        `env:"TTLOCK_SERVER" env-default:"https://euapi.ttlock.com/"`
-		ClientID        string        `env:"TTLOCK_CLIENT_ID"`
-		ClientSecret    string        `env:"TTLOCK_CLIENT_SECRET"`
-		EnableCallback  bool          `env:"TTLOCK_ENABLE_CALLBACK" env-default:"false"`
-		RefreshInterval time.Duration `env:"REFRESH_INTERVAL" env-default:"1m"`
+		Server           string        `env:"TTLOCK_SERVER" env-default:"https://euapi.ttlock.com/"`
+		ClientID         string        `env:"TTLOCK_CLIENT_ID"`
+		ClientSecret     string        `env:"TTLOCK_CLIENT_SECRET"`
+		EnableCallback   bool          `env:"TTLOCK_ENABLE_CALLBACK" env-default:"false"`
+		CallbackSecret   string        `env:"TTLOCK_CALLBACK_SECRET"`
+		RefreshInterval  time.Duration `env:"REFRESH_INTERVAL" env-default:"1m"`
+		PollWorkers      int           `env:"POLL_WORKERS" env-default:"4"`
+		PollJitter       float64       `env:"POLL_JITTER" env-default:"0.1"`
+		PollMaxBackoff   time.Duration `env:"POLL_MAX_BACKOFF" env-default:"30m"`
+		FastPollRate     time.Duration `env:"FAST_POLL_INTERVAL" env-default:"5s"`
+		FastPollWindow   time.Duration `env:"FAST_POLL_WINDOW" env-default:"1m"`
+		IdlePollRate     time.Duration `env:"IDLE_POLL_INTERVAL" env-default:"5m"`
+		IdlePollAfter    time.Duration `env:"IDLE_POLL_AFTER" env-default:"1h"`
+		CommandTimeout   time.Duration `env:"COMMAND_CONFIRM_TIMEOUT" env-default:"30s"`
+		RateLimit        int           `env:"TTLOCK_RATE_LIMIT" env-default:"120"`
+		DetailsInterval  time.Duration `env:"DETAILS_REFRESH_INTERVAL" env-default:"1h"`
+		SyncInterval     time.Duration `env:"METADATA_SYNC_INTERVAL" env-default:"6h"`
+		RecordsInterval  time.Duration `env:"RECORDS_REFRESH_INTERVAL" env-default:"5m"`
+		GatewaysInterval time.Duration `env:"GATEWAYS_REFRESH_INTERVAL" env-default:"5m"`
+
+		TokenRefreshMargin time.Duration `env:"TTLOCK_TOKEN_REFRESH_MARGIN" env-default:"24h"`
+	}
+	Auth struct {
+		Username       string        `env:"AUTH_USERNAME"`
+		Password       string        `env:"AUTH_PASSWORD"`
+		PasswordHash   string        `env:"AUTH_PASSWORD_HASH"`
+		SessionSecret  string        `env:"AUTH_SESSION_SECRET"`
+		SessionTTL     time.Duration `env:"AUTH_SESSION_TTL" env-default:"24h"`
+		ProxyHeader    string        `env:"AUTH_PROXY_HEADER"`
+		TrustedProxies []string      `env:"AUTH_TRUSTED_PROXIES"`
 	}
 	Storage struct {
-		FilePath string `env:"STORAGE_FILE" env-default:"./storage.json"`
+		Backend    string `env:"STORAGE_BACKEND" env-default:"json"`
+		FilePath   string `env:"STORAGE_FILE" env-default:"./storage.json"`
+		SqlitePath string `env:"STORAGE_SQLITE_FILE" env-default:"./storage.db"`
+
+		EncryptionKey     string   `env:"STORAGE_ENCRYPTION_KEY"`
+		EncryptionKeyFile string   `env:"STORAGE_ENCRYPTION_KEY_FILE"`
+		OldEncryptionKeys []string `env:"STORAGE_ENCRYPTION_OLD_KEYS"`
 	}
 	Mqtt struct {
 		//""
@@ -22,5 +53,21 @@ type Config struct {
 		ClientID string `env:"MQTT_CLIENT_ID" env-default:"ttlock2mqtt"`
 		Username string `env:"MQTT_USERNAME"`
 		Password string `env:"MQTT_PASSWORD"`
+
+		PersistentSession bool `env:"MQTT_PERSISTENT_SESSION" env-default:"false"`
+
+		CACert             string   `env:"MQTT_CA_CERT"`
+		ClientCert         string   `env:"MQTT_CLIENT_CERT"`
+		ClientKey          string   `env:"MQTT_CLIENT_KEY"`
+		InsecureSkipVerify bool     `env:"MQTT_INSECURE_SKIP_VERIFY" env-default:"false"`
+		ServerName         string   `env:"MQTT_TLS_SERVER_NAME"`
+		ALPN               []string `env:"MQTT_TLS_ALPN"`
+
+		BaseTopic       string `env:"MQTT_BASE_TOPIC" env-default:"ttlock2mqtt"`
+		DiscoveryPrefix string `env:"MQTT_DISCOVERY_PREFIX" env-default:"homeassistant"`
+		NodeID          string `env:"MQTT_NODE_ID" env-default:"ttlock2mqtt"`
+		AliasTopics     bool   `env:"MQTT_ALIAS_TOPICS" env-default:"false"`
+
+		BatteryLowThreshold int32 `env:"BATTERY_LOW_THRESHOLD" env-default:"20"`
 	}
 }
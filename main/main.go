@@ -1,6 +1,8 @@
 package main
 
 import (
+	"database/sql"
+	"fmt"
 	"log"
 	"os"
 
@@ -10,7 +12,9 @@ import (
 	"github.com/nikolai5slo/ttlock2mqtt/locks"
 	"github.com/nikolai5slo/ttlock2mqtt/mqtt"
 	"github.com/nikolai5slo/ttlock2mqtt/server"
+	"github.com/nikolai5slo/ttlock2mqtt/server/auth"
 	"github.com/nikolai5slo/ttlock2mqtt/server/handlers"
+	"github.com/nikolai5slo/ttlock2mqtt/sqlite"
 	"github.com/nikolai5slo/ttlock2mqtt/ttlock"
 	ttlockapi "github.com/nikolai5slo/ttlock2mqtt/ttlock-api"
 	"github.com/schollz/jsonstore"
@@ -20,9 +24,12 @@ type deps struct {
 	cfg                Config
 	ttlockService      ttlock.Service
 	server             *server.Server
+	auth               *auth.Auth
 	handlers           *handlers.Handlers
 	lockStorage        locks.Storage
 	credentialsStorage credentials.Storage
+	encryptedStorage   *credentials.EncryptedStore
+	db                 *sql.DB
 	mqtt               *mqtt.HAMqtt
 	controller         *controller.Controller
 }
@@ -46,15 +53,82 @@ func (d *deps) buildTTLockService() error {
 		return err
 	}
 
-	d.ttlockService, err = ttlock.New(
+	tokenManager, err := credentials.NewTokenManager(
+		credentials.WithTokenStorage(d.credentialsStorage),
+		credentials.WithRefreshMargin(d.cfg.TTLock.TokenRefreshMargin),
+	)
+
+	if err != nil {
+		return err
+	}
+
+	service, err := ttlock.New(
 		ttlock.WithTTLockClient(ttlockClient),
 		ttlock.WithClientSecret(d.cfg.TTLock.ClientID, d.cfg.TTLock.ClientSecret),
+		ttlock.WithTokenSource(tokenManager),
 	)
 
-	return err
+	if err != nil {
+		return err
+	}
+
+	tokenManager.SetRefresher(service)
+	d.ttlockService = service
+
+	return nil
 }
 
 func (d *deps) buildStorages() (err error) {
+	switch d.cfg.Storage.Backend {
+	case "json":
+		err = d.buildJsonStorages()
+	case "sqlite":
+		err = d.buildSqliteStorages()
+	default:
+		err = fmt.Errorf("unknown storage backend: %s", d.cfg.Storage.Backend)
+	}
+
+	if err != nil {
+		return
+	}
+
+	return d.buildEncryption()
+}
+
+func (d *deps) buildEncryption() error {
+	key := d.cfg.Storage.EncryptionKey
+
+	if d.cfg.Storage.EncryptionKeyFile != "" {
+		data, err := os.ReadFile(d.cfg.Storage.EncryptionKeyFile)
+
+		if err != nil {
+			return fmt.Errorf("cannot read encryption key: %w", err)
+		}
+
+		key = string(data)
+	}
+
+	if key == "" {
+		return nil
+	}
+
+	encrypted, err := credentials.NewEncryptedStore(
+		d.credentialsStorage,
+		credentials.WithEncryptionKey(key),
+		credentials.WithDecryptionKeys(d.cfg.Storage.OldEncryptionKeys...),
+	)
+
+	if err != nil {
+		return err
+	}
+
+	d.encryptedStorage = encrypted
+	d.credentialsStorage = encrypted
+
+	return nil
+}
+
+func (d *deps) buildJsonStorages() (err error) {
 	// Credentials storage
 	d.credentialsStorage, err = credentials.NewJsonStore(new(jsonstore.JSONStore), d.cfg.Storage.FilePath)
 
@@ -68,12 +142,122 @@ func (d *deps) buildStorages() (err error) {
 	return
 }
 
+func (d *deps) buildSqliteStorages() (err error) {
+	d.db, err = sqlite.Open(d.cfg.Storage.SqlitePath)
+
+	if err != nil {
+		return fmt.Errorf("cannot open sqlite database: %w", err)
+	}
+
+	// Credentials storage
+	d.credentialsStorage, err = credentials.NewSqliteStore(d.db)
+
+	if err != nil {
+		return
+	}
+
+	// Lock store
+	d.lockStorage, err = locks.NewSqliteStore(d.db)
+
+	if err != nil {
+		return
+	}
+
+	return d.importJsonStorage()
+}
+
+// importJsonStorage moves data from an existing JSON storage file into the current storages, once
+func (d *deps) importJsonStorage() error {
+	if _, err := os.Stat(d.cfg.Storage.FilePath); os.IsNotExist(err) {
+		return nil
+	}
+
+	creds := credentials.CredentialsList{}
+	mLocks := locks.LockList{}
+
+	if err := d.credentialsStorage.Load(&creds); err != nil {
+		return err
+	}
+
+	if err := d.lockStorage.Load(&mLocks); err != nil {
+		return err
+	}
+
+	if len(creds) > 0 || len(mLocks) > 0 {
+		log.Printf("storage is not empty, skipping import of %s", d.cfg.Storage.FilePath)
+		return nil
+	}
+
+	jsonCreds, err := credentials.NewJsonStore(new(jsonstore.JSONStore), d.cfg.Storage.FilePath)
+
+	if err != nil {
+		return err
+	}
+
+	jsonLocks, err := locks.NewJsonStore(new(jsonstore.JSONStore), d.cfg.Storage.FilePath)
+
+	if err != nil {
+		return err
+	}
+
+	if err := jsonCreds.Load(&creds); err != nil {
+		return fmt.Errorf("cannot read credentials from %s: %w", d.cfg.Storage.FilePath, err)
+	}
+
+	if err := jsonLocks.Load(&mLocks); err != nil {
+		return fmt.Errorf("cannot read locks from %s: %w", d.cfg.Storage.FilePath, err)
+	}
+
+	if err := d.credentialsStorage.Save(creds); err != nil {
+		return fmt.Errorf("cannot import credentials: %w", err)
+	}
+
+	if err := d.lockStorage.Save(mLocks); err != nil {
+		return fmt.Errorf("cannot import locks: %w", err)
+	}
+
+	// Rename so the import does not repeat if the storage gets emptied later
+	if err := os.Rename(d.cfg.Storage.FilePath, d.cfg.Storage.FilePath+".imported"); err != nil {
+		return fmt.Errorf("cannot rename imported file: %w", err)
+	}
+
+	log.Printf("imported %d credentials and %d locks from %s", len(creds), len(mLocks), d.cfg.Storage.FilePath)
+
+	return nil
+}
+
+func (d *deps) buildAuth() (err error) {
+	opts := []auth.Conf{
+		auth.WithSessionSecret(d.cfg.Auth.SessionSecret),
+		auth.WithSessionTTL(d.cfg.Auth.SessionTTL),
+		auth.WithProxyHeader(d.cfg.Auth.ProxyHeader, d.cfg.Auth.TrustedProxies),
+	}
+
+	if d.cfg.Auth.PasswordHash != "" {
+		opts = append(opts, auth.WithPasswordHash(d.cfg.Auth.Username, d.cfg.Auth.PasswordHash))
+	} else {
+		opts = append(opts, auth.WithCredentials(d.cfg.Auth.Username, d.cfg.Auth.Password))
+	}
+
+	d.auth, err = auth.New(opts...)
+	return
+}
+
 func (d *deps) buildHandlers() (err error) {
-	d.handlers, err = handlers.New(
+	opts := []handlers.Conf{
 		handlers.WithLockStorage(d.lockStorage),
 		handlers.WithCredentialsStorage(d.credentialsStorage),
 		handlers.WithTTlockService(d.ttlockService),
-	)
+		handlers.WithLockCommander(d.controller),
+		handlers.WithChangeListener(d.controller),
+		handlers.WithAuth(d.auth),
+	}
+
+	if d.cfg.TTLock.EnableCallback {
+		opts = append(opts, handlers.WithRecordsReceiver(d.controller, d.cfg.TTLock.CallbackSecret))
+	}
+
+	d.handlers, err = handlers.New(opts...)
 	return
 }
 
@@ -90,6 +274,17 @@ func (d *deps) buildMqtt() (err error) {
 		mqtt.WithBroker(d.cfg.Mqtt.Broker),
 		mqtt.WithClientID(d.cfg.Mqtt.ClientID),
 		mqtt.WithCredentials(d.cfg.Mqtt.Username, d.cfg.Mqtt.Password),
+		mqtt.WithPersistentSession(d.cfg.Mqtt.PersistentSession),
+		mqtt.WithCACert(d.cfg.Mqtt.CACert),
+		mqtt.WithClientCertificate(d.cfg.Mqtt.ClientCert, d.cfg.Mqtt.ClientKey),
+		mqtt.WithInsecureSkipVerify(d.cfg.Mqtt.InsecureSkipVerify),
+		mqtt.WithServerName(d.cfg.Mqtt.ServerName),
+		mqtt.WithALPN(d.cfg.Mqtt.ALPN...),
+		mqtt.WithBaseTopic(d.cfg.Mqtt.BaseTopic),
+		mqtt.WithDiscoveryPrefix(d.cfg.Mqtt.DiscoveryPrefix),
+		mqtt.WithNodeID(d.cfg.Mqtt.NodeID),
+		mqtt.WithAliasTopics(d.cfg.Mqtt.AliasTopics),
+		mqtt.WithBatteryLowThreshold(d.cfg.Mqtt.BatteryLowThreshold),
 	)
 	return
 }
@@ -101,6 +296,14 @@ func (d *deps) buildController() (err error) {
 		controller.WithMqtt(d.mqtt),
 		controller.WithTTlockService(d.ttlockService),
 		controller.WithRefreshRate(d.cfg.TTLock.RefreshInterval),
+		controller.WithPolling(d.cfg.TTLock.PollWorkers, d.cfg.TTLock.PollJitter, d.cfg.TTLock.PollMaxBackoff),
+		controller.WithAdaptivePolling(d.cfg.TTLock.FastPollRate, d.cfg.TTLock.FastPollWindow, d.cfg.TTLock.IdlePollRate, d.cfg.TTLock.IdlePollAfter),
+		controller.WithCommandTimeout(d.cfg.TTLock.CommandTimeout),
+		controller.WithRateLimit(d.cfg.TTLock.RateLimit),
+		controller.WithDetailsRefreshRate(d.cfg.TTLock.DetailsInterval),
+		controller.WithSyncRate(d.cfg.TTLock.SyncInterval),
+		controller.WithRecordsRefreshRate(d.cfg.TTLock.RecordsInterval),
+		controller.WithGatewaysRefreshRate(d.cfg.TTLock.GatewaysInterval),
 	)
 	return
 }
@@ -110,12 +313,13 @@ func buildDeps() (*deps, error) {
 
 	fList := []func() error{
 		d.buildConfig,
-		d.buildTTLockService,
 		d.buildStorages,
-		d.buildHandlers,
-		d.buildServer,
+		d.buildTTLockService,
 		d.buildMqtt,
 		d.buildController,
+		d.buildAuth,
+		d.buildHandlers,
+		d.buildServer,
 	}
 
 	for _, f := range fList {
@@ -128,6 +332,12 @@ func buildDeps() (*deps, error) {
 }
 
 func main() {
+	if len(os.Args) > 1 {
+		if err := runCommand(os.Args[1]); err != nil {
+			log.Fatalf("%s failed: %s", os.Args[1], err)
+		}
+		return
+	}
 
 	d, err := buildDeps()
 
@@ -137,6 +347,14 @@ func main() {
 
 	defer d.controller.Close()
 
+	if d.db != nil {
+		defer d.db.Close()
+	}
+
+	if err := d.mqtt.Connect(); err != nil {
+		log.Printf("mqtt broker not reachable yet, retrying in background: %s", err)
+	}
+
 	d.controller.StartAutoRefresh()
 
 	err = d.server.Run()
@@ -0,0 +1,100 @@
+package mqtt
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/nikolai5slo/ttlock2mqtt/locks"
+	"github.com/nikolai5slo/ttlock2mqtt/ttlock"
+)
+
+// Lock features published in the attributes, by attribute name
+var lockFeatures = map[string]ttlock.Feature{
+	"passcode":     ttlock.FeaturePasscode,
+	"card":         ttlock.FeatureCard,
+	"fingerprint":  ttlock.FeatureFingerprint,
+	"wristband":    ttlock.FeatureWristband,
+	"auto_lock":    ttlock.FeatureAutoLock,
+	"passage_mode": ttlock.FeaturePassageMode,
+}
+
+// LockPollInfo is the bridge side lock state published with the lock attributes
+type LockPollInfo struct {
+	Account   string
+	LastPoll  time.Time
+	LastError error
+}
+
+type MqttLockAttributes struct {
+	LockID     int32           `json:"lock_id"`
+	LockName   string          `json:"lock_name"`
+	Mac        string          `json:"mac,omitempty"`
+	GroupID    *int32          `json:"group_id,omitempty"`
+	GroupName  string          `json:"group_name,omitempty"`
+	HasGateway bool            `json:"has_gateway"`
+	Battery    *int32          `json:"battery,omitempty"`
+	Features   map[string]bool `json:"features,omitempty"`
+	InitDate   *time.Time      `json:"init_date,omitempty"`
+	Account    string          `json:"account,omitempty"`
+	LastPoll   *time.Time      `json:"last_poll,omitempty"`
+	LastError  string          `json:"last_error,omitempty"`
+	Missing    bool            `json:"missing"`
+}
+
+// UpdateLockAttributes publishes lock metadata on the lock attributes topic
+func (m *HAMqtt) UpdateLockAttributes(l locks.ManagedLock, info LockPollInfo) error {
+	attrs := MqttLockAttributes{
+		LockID:     l.LockId,
+		LockName:   l.LockName,
+		GroupID:    l.GroupId,
+		HasGateway: l.HasGateway != nil && *l.HasGateway == 1,
+		Battery:    l.ElectricQuantity,
+		Account:    info.Account,
+		Missing:    l.Missing,
+	}
+
+	if l.LockMac != nil {
+		attrs.Mac = *l.LockMac
+	}
+
+	if l.GroupName != nil {
+		attrs.GroupName = *l.GroupName
+	}
+
+	if l.FeatureValue != nil {
+		attrs.Features = lockFeatureFlags(l.Lock)
+	}
+
+	if l.Date != nil {
+		date := time.UnixMilli(*l.Date)
+		attrs.InitDate = &date
+	}
+
+	if !info.LastPoll.IsZero() {
+		attrs.LastPoll = &info.LastPoll
+	}
+
+	if info.LastError != nil {
+		attrs.LastError = info.LastError.Error()
+	}
+
+	payload, err := json.Marshal(attrs)
+
+	if err != nil {
+		return fmt.Errorf("could not serialize lock attributes: %w", err)
+	}
+
+	return m.publish(m.lockTopic(l, "attributes"), true, string(payload))
+}
+
+// lockFeatureFlags decodes the feature value of the lock into named flags
+func lockFeatureFlags(l ttlock.Lock) map[string]bool {
+	flags := map[string]bool{}
+
+	for name, f := range lockFeatures {
+		flags[name] = ttlock.SupportsFeature(l, f)
+	}
+
+	return flags
+}
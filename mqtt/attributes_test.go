@@ -0,0 +1,30 @@
+package mqtt
+
+import (
+	"testing"
+
+	"github.com/nikolai5slo/ttlock2mqtt/ttlock"
+)
+
+func TestLockFeatureFlags(t *testing.T) {
+	// Passcode, card, auto lock and passage mode
+	value := "400013"
+	l := ttlock.Lock{FeatureValue: &value}
+
+	flags := lockFeatureFlags(l)
+
+	want := map[string]bool{
+		"passcode":     true,
+		"card":         true,
+		"fingerprint":  false,
+		"wristband":    false,
+		"auto_lock":    true,
+		"passage_mode": true,
+	}
+
+	for name, supported := range want {
+		if flags[name] != supported {
+			t.Errorf("feature %s = %t, want %t", name, flags[name], supported)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package mqtt
+
+import (
+	"fmt"
+
+	"github.com/nikolai5slo/ttlock2mqtt/locks"
+)
+
+type MqttSensorConfig struct {
+	StateTopic        string             `json:"state_topic"`
+	Name              string             `json:"name"`
+	UniqueID          string             `json:"unique_id"`
+	DeviceClass       string             `json:"device_class,omitempty"`
+	StateClass        string             `json:"state_class,omitempty"`
+	UnitOfMeasurement string             `json:"unit_of_measurement,omitempty"`
+	EntityCategory    string             `json:"entity_category,omitempty"`
+	PayloadOn         string             `json:"payload_on,omitempty"`
+	PayloadOff        string             `json:"payload_off,omitempty"`
+	Device            MqttDevice         `json:"device"`
+	Availability      []MqttAvailability `json:"availability"`
+	AvailabilityMode  string             `json:"availability_mode"`
+}
+
+func (m *HAMqtt) introduceBatterySensors(l locks.ManagedLock) error {
+	battery := &MqttSensorConfig{
+		StateTopic:        m.lockTopic(l, "battery"),
+		Name:              fmt.Sprintf("%s Battery", l.LockAlias),
+		UniqueID:          fmt.Sprintf("%d_battery", l.LockId),
+		DeviceClass:       "battery",
+		StateClass:        "measurement",
+		UnitOfMeasurement: "%",
+		EntityCategory:    "diagnostic",
+		Device:            m.lockDevice(l),
+		Availability:      m.lockAvailability(l),
+		AvailabilityMode:  "all",
+	}
+
+	configTopics := m.batteryConfigTopics(l)
+
+	err := m.publishConfig(configTopics[0], battery)
+
+	if err != nil {
+		return err
+	}
+
+	batteryLow := &MqttSensorConfig{
+		StateTopic:       m.lockTopic(l, "battery_low"),
+		Name:             fmt.Sprintf("%s Battery Low", l.LockAlias),
+		UniqueID:         fmt.Sprintf("%d_battery_low", l.LockId),
+		DeviceClass:      "battery",
+		EntityCategory:   "diagnostic",
+		PayloadOn:        "ON",
+		PayloadOff:       "OFF",
+		Device:           m.lockDevice(l),
+		Availability:     m.lockAvailability(l),
+		AvailabilityMode: "all",
+	}
+
+	return m.publishConfig(configTopics[1], batteryLow)
+}
+
+func (m *HAMqtt) batteryConfigTopics(l locks.ManagedLock) []string {
+	return []string{
+		m.discoveryTopic("sensor", fmt.Sprintf("%d_battery", l.LockId)),
+		m.discoveryTopic("binary_sensor", fmt.Sprintf("%d_battery_low", l.LockId)),
+	}
+}
+
+// UpdateLockBattery publishes battery percentage and the derived low battery state
+func (m *HAMqtt) UpdateLockBattery(l locks.ManagedLock, percent int32) error {
+	err := m.publish(m.lockTopic(l, "battery"), true, fmt.Sprint(percent))
+
+	if err != nil {
+		return err
+	}
+
+	low := "OFF"
+	if percent <= m.batteryLowThreshold {
+		low = "ON"
+	}
+
+	return m.publish(m.lockTopic(l, "battery_low"), true, low)
+}
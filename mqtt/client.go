@@ -1,10 +1,12 @@
 package mqtt
 
 import (
+	"crypto/tls"
 	"encoding/json"
-	"errors"
 	"fmt"
+	"log"
 	"strings"
+	"sync"
 	"time"
 
 	mqtt "github.com/eclipse/paho.mqtt.golang"
@@ -16,30 +18,79 @@ type HAMqtt struct {
 	opts    *mqtt.ClientOptions
 	client  mqtt.Client
 	timeout time.Duration
+
+	tlsConfig *tls.Config
+
+	baseTopic       string
+	discoveryPrefix string
+	nodeID          string
+	aliasTopics     bool
+
+	reannounce   func()
+	onConnection func(connected bool)
+
+	mu            sync.Mutex
+	subscriptions map[string]mqtt.MessageHandler
+	queue         []queuedMessage
+	lockGateways  map[int32]int32
+
+	// Locks using lock id topics as their alias is taken
+	aliasCollisions map[int32]bool
+	keysMu          sync.RWMutex
+
+	batteryLowThreshold int32
 }
 
+const (
+	payloadOnline  = "online"
+	payloadOffline = "offline"
+)
+
 type MqttLockConfig struct {
-	CommandTopic string     `json:"command_topic"`
-	StateTopic   string     `json:"state_topic"`
-	Name         string     `json:"name"`
-	UniqueID     string     `json:"unique_id"`
-	Device       MqttDevice `json:"device"`
+	CommandTopic        string             `json:"command_topic"`
+	StateTopic          string             `json:"state_topic"`
+	JsonAttributesTopic string             `json:"json_attributes_topic"`
+	Name                string             `json:"name"`
+	UniqueID            string             `json:"unique_id"`
+	Device              MqttDevice         `json:"device"`
+	Availability        []MqttAvailability `json:"availability"`
+	AvailabilityMode    string             `json:"availability_mode"`
+}
+type MqttAvailability struct {
+	Topic               string `json:"topic"`
+	PayloadAvailable    string `json:"payload_available"`
+	PayloadNotAvailable string `json:"payload_not_available"`
 }
 type MqttDevice struct {
-	Name        string   `json:"name"`
-	Model       string   `json:"model"`
-	Identifiers []string `json:"identifiers"`
+	Name         string      `json:"name"`
+	Model        string      `json:"model"`
+	Manufacturer string      `json:"manufacturer,omitempty"`
+	SwVersion    string      `json:"sw_version,omitempty"`
+	HwVersion    string      `json:"hw_version,omitempty"`
+	Identifiers  []string    `json:"identifiers"`
+	Connections  [][2]string `json:"connections,omitempty"`
+	ViaDevice    string      `json:"via_device,omitempty"`
 }
 
 type Conf func(*HAMqtt) error
 
 func New(cfg ...Conf) (*HAMqtt, error) {
 	mqt := &HAMqtt{
-		timeout: 2 * time.Second,
+		timeout:             2 * time.Second,
+		baseTopic:           "ttlock2mqtt",
+		discoveryPrefix:     "homeassistant",
+		nodeID:              "ttlock2mqtt",
+		batteryLowThreshold: 20,
+		subscriptions:       map[string]mqtt.MessageHandler{},
+		lockGateways:        map[int32]int32{},
 	}
 
 	mqt.opts = mqtt.NewClientOptions()
 	mqt.opts.SetAutoReconnect(true)
+	mqt.opts.SetConnectRetry(true)
+
+	// Command handlers call the TTLock API, do not block other messages meanwhile
+	mqt.opts.SetOrderMatters(false)
 
 	for _, c := range cfg {
 		if err := c(mqt); err != nil {
@@ -47,6 +98,23 @@ func New(cfg ...Conf) (*HAMqtt, error) {
 		}
 	}
 
+	// Brokers with ssl://, tls:// or wss:// URLs use the system roots unless configured
+	if mqt.tlsConfig != nil {
+		mqt.opts.SetTLSConfig(mqt.tlsConfig)
+	}
+
+	// Bridge availability, broker publishes offline if we disappear
+	mqt.opts.SetWill(mqt.bridgeAvailabilityTopic(), payloadOffline, 1, true)
+	mqt.opts.SetOnConnectHandler(mqt.onConnect)
+	mqt.opts.SetConnectionLostHandler(mqt.onConnectionLost)
+
+	// Home Assistant announces itself after restarts, retained configs may be gone
+	mqt.subscriptions[mqt.discoveryPrefix+"/status"] = func(c mqtt.Client, msg mqtt.Message) {
+		if string(msg.Payload()) == payloadOnline {
+			mqt.triggerReannounce()
+		}
+	}
+
 	mqt.client = mqtt.NewClient(mqt.opts)
 
 	return mqt, nil
@@ -74,11 +142,34 @@ func WithCredentials(username string, password string) Conf {
 	}
 }
 
+func WithBatteryLowThreshold(percent int32) Conf {
+	return func(h *HAMqtt) error {
+		if percent < 0 || percent > 100 {
+			return fmt.Errorf("battery low threshold out of range: %d", percent)
+		}
+		h.batteryLowThreshold = percent
+		return nil
+	}
+}
+
+// SetReannounceHandler sets the function that re-publishes all lock configs and states,
+// it is called on every (re)connect and when Home Assistant comes online
+func (m *HAMqtt) SetReannounceHandler(f func()) {
+	m.reannounce = f
+}
+
+func (m *HAMqtt) triggerReannounce() {
+	if m.reannounce != nil {
+		m.reannounce()
+	}
+}
+
+// Connect starts connecting to the broker, the client keeps retrying in the background on failure
 func (m *HAMqtt) Connect() error {
 	if !m.client.IsConnected() {
 		token := m.client.Connect()
 		if !token.WaitTimeout(m.timeout) {
-			return fmt.Errorf("mqtt connection timeout: %w", token.Error())
+			return fmt.Errorf("mqtt connection timeout")
 		}
 		return token.Error()
 	}
@@ -86,37 +177,43 @@ func (m *HAMqtt) Connect() error {
 }
 
 func (m *HAMqtt) Close() error {
+	if m.client.IsConnected() {
+		token := m.client.Publish(m.bridgeAvailabilityTopic(), 1, true, payloadOffline)
+		token.WaitTimeout(m.timeout)
+	}
+
 	m.client.Disconnect(1)
 	return nil
 }
 
-func (m *HAMqtt) MqttLockCommandCallback(l locks.ManagedLock, callback func(ttlock.LockStatus)) error {
-	token := m.client.Subscribe(fmt.Sprintf("ttlock2mqtt/%d/command", l.LockId), 1, func(c mqtt.Client, m mqtt.Message) {
-		switch string(m.Payload()) {
-		case "LOCK":
-			callback(ttlock.Locked)
-		case "UNLOCK":
-			callback(ttlock.Unlocked)
-		}
-	})
+func (m *HAMqtt) MqttLockCommandCallback(l locks.ManagedLock, callback func(LockCommand)) error {
+	return m.subscribe(m.lockTopic(l, "command"), func(c mqtt.Client, msg mqtt.Message) {
+		cmd, err := parseLockCommand(msg.Payload())
+
+		if err != nil {
+			log.Printf("ignoring command for lock %d: %s", l.LockId, err)
 
-	token.WaitTimeout(m.timeout)
+			if err := m.PublishCommandResult(l, cmd, 0, err); err != nil {
+				log.Printf("failed to publish command result: %s", err)
+			}
+			return
+		}
 
-	return token.Error()
+		callback(cmd)
+	})
 }
 
 // Introduce
 func (m *HAMqtt) IntroduceLock(l locks.ManagedLock) error {
 	lockConfig := &MqttLockConfig{
-		CommandTopic: fmt.Sprintf("ttlock2mqtt/%d/command", l.LockId),
-		StateTopic:   fmt.Sprintf("ttlock2mqtt/%d/state", l.LockId),
-		Name:         l.LockAlias,
-		UniqueID:     fmt.Sprint(l.LockId),
-		Device: MqttDevice{
-			Name:        l.LockAlias,
-			Model:       l.LockName,
-			Identifiers: []string{*l.LockMac, fmt.Sprint(l.LockId)},
-		},
+		CommandTopic:        m.lockTopic(l, "command"),
+		StateTopic:          m.lockTopic(l, "state"),
+		JsonAttributesTopic: m.lockTopic(l, "attributes"),
+		Name:                l.LockAlias,
+		UniqueID:            fmt.Sprint(l.LockId),
+		Device:              m.lockDevice(l),
+		Availability:        m.lockAvailability(l),
+		AvailabilityMode:    "all",
 	}
 
 	payload, err := json.Marshal(lockConfig)
@@ -125,55 +222,157 @@ func (m *HAMqtt) IntroduceLock(l locks.ManagedLock) error {
 		return fmt.Errorf("could not serialize lock config object: %w", err)
 	}
 
-	return m.handleError(3, func() error {
-		token := m.client.Publish(fmt.Sprintf("homeassistant/lock/ttlock2mqtt/%d/config", l.LockId), 1, true, string(payload))
+	err = m.publish(m.discoveryTopic("lock", fmt.Sprint(l.LockId)), true, string(payload))
+
+	if err != nil {
+		return err
+	}
 
-		token.WaitTimeout(1 * m.timeout)
+	if err := m.introduceBatterySensors(l); err != nil {
+		return err
+	}
 
-		return token.Error()
+	if ttlock.SupportsFeature(l.Lock, ttlock.FeaturePassageMode) {
+		if err := m.introducePassageMode(l); err != nil {
+			return err
+		}
+	} else if err := m.clearRetained(m.passageModeConfigTopics(l)); err != nil {
+		return err
+	}
+
+	return m.introduceEvents(l)
+}
+
+// RetireLock stops listening for lock commands and removes the lock from Home Assistant
+func (m *HAMqtt) RetireLock(l locks.ManagedLock) error {
+	if err := m.ReleaseTopics(l); err != nil {
+		return err
+	}
+
+	// Empty retained payload deletes the entity in Home Assistant
+	topics := []string{m.discoveryTopic("lock", fmt.Sprint(l.LockId))}
+	topics = append(topics, m.batteryConfigTopics(l)...)
+	topics = append(topics, m.eventConfigTopics(l)...)
+	topics = append(topics, m.passageModeConfigTopics(l)...)
+
+	return m.clearRetained(topics)
+}
+
+// ReleaseTopics stops listening for lock commands and clears retained lock state,
+// used when a lock is retired or moves to other topics
+func (m *HAMqtt) ReleaseTopics(l locks.ManagedLock) error {
+	if err := m.unsubscribe(m.lockTopic(l, "command")); err != nil {
+		return fmt.Errorf("cannot unsubscribe lock commands: %w", err)
+	}
+
+	if err := m.releasePassageModeTopics(l); err != nil {
+		return err
+	}
+
+	return m.clearRetained([]string{
+		m.lockTopic(l, "availability"),
+		m.lockTopic(l, "attributes"),
+		m.lockTopic(l, "battery"),
+		m.lockTopic(l, "battery_low"),
 	})
 }
 
+func (m *HAMqtt) clearRetained(topics []string) error {
+	for _, topic := range topics {
+		if err := m.publish(topic, true, ""); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *HAMqtt) lockDevice(l locks.ManagedLock) MqttDevice {
+	identifiers := []string{fmt.Sprint(l.LockId)}
+	if l.LockMac != nil {
+		identifiers = append([]string{*l.LockMac}, identifiers...)
+	}
+
+	device := MqttDevice{
+		Name:         l.LockAlias,
+		Model:        l.LockName,
+		Manufacturer: "TTLock",
+		Identifiers:  identifiers,
+	}
+
+	if l.ModelNum != nil && *l.ModelNum != "" {
+		device.Model = *l.ModelNum
+	}
+
+	if l.FirmwareRevision != nil {
+		device.SwVersion = *l.FirmwareRevision
+	}
+
+	if l.HardwareRevision != nil {
+		device.HwVersion = *l.HardwareRevision
+	}
+
+	if l.LockMac != nil {
+		device.Connections = [][2]string{{"mac", strings.ToLower(*l.LockMac)}}
+	}
+
+	if gatewayID := m.LockGateway(l.LockId); gatewayID != 0 {
+		device.ViaDevice = gatewayIdentifier(gatewayID)
+	}
+
+	return device
+}
+
+func (m *HAMqtt) lockAvailability(l locks.ManagedLock) []MqttAvailability {
+	return []MqttAvailability{
+		{
+			Topic:               m.bridgeAvailabilityTopic(),
+			PayloadAvailable:    payloadOnline,
+			PayloadNotAvailable: payloadOffline,
+		},
+		{
+			Topic:               m.lockTopic(l, "availability"),
+			PayloadAvailable:    payloadOnline,
+			PayloadNotAvailable: payloadOffline,
+		},
+	}
+}
+
 func (m *HAMqtt) UpdateLockStatus(l locks.ManagedLock, status ttlock.LockStatus) error {
-	txtStatus := ""
+	// Home Assistant resets the lock to unknown state on None
+	txtStatus := "None"
 
 	switch status {
 	case ttlock.Locked:
 		txtStatus = "LOCKED"
 	case ttlock.Unlocked:
 		txtStatus = "UNLOCKED"
+	case ttlock.Locking:
+		txtStatus = "LOCKING"
+	case ttlock.Unlocking:
+		txtStatus = "UNLOCKING"
+	case ttlock.Jammed:
+		txtStatus = "JAMMED"
 	}
 
-	if txtStatus != "" {
-		return m.handleError(3, func() error {
-			token := m.client.Publish(fmt.Sprintf("ttlock2mqtt/%d/state", l.LockId), 1, false, txtStatus)
-
-			token.WaitTimeout(1 * m.timeout)
+	return m.publish(m.lockTopic(l, "state"), false, txtStatus)
+}
 
-			return token.Error()
-		})
+func (m *HAMqtt) UpdateLockAvailability(l locks.ManagedLock, online bool) error {
+	payload := payloadOffline
+	if online {
+		payload = payloadOnline
 	}
 
-	return nil
+	return m.publish(m.lockTopic(l, "availability"), true, payload)
 }
 
-func (m *HAMqtt) handleError(retryCount int, closure func() error) error {
-	err := errors.New("no execution")
-
-	for retryCount >= 0 && err != nil {
-		err = closure()
+func (m *HAMqtt) publishConfig(topic string, config interface{}) error {
+	payload, err := json.Marshal(config)
 
-		if err != nil && strings.Contains(strings.ToLower(err.Error()), "not connected") {
-			err = m.Connect()
-
-			// Do not retry if reconnection failed
-			if err != nil {
-				return err
-			}
-		}
-
-		retryCount--
+	if err != nil {
+		return fmt.Errorf("could not serialize config object: %w", err)
 	}
 
-	return err
+	return m.publish(topic, true, string(payload))
 }
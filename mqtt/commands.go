@@ -0,0 +1,110 @@
+package mqtt
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/nikolai5slo/ttlock2mqtt/locks"
+	"github.com/nikolai5slo/ttlock2mqtt/ttlock"
+)
+
+// LockCommand is a command received on the lock command topic. Besides plain LOCK and UNLOCK
+// payloads a JSON object is accepted, its response topic and correlation id mirror the
+// MQTT v5 properties for clients limited to MQTT 3.1.1.
+type LockCommand struct {
+	Status        ttlock.LockStatus
+	Name          string
+	CorrelationID string
+	ResponseTopic string
+}
+
+type mqttLockCommand struct {
+	Command       string `json:"command"`
+	CorrelationID string `json:"correlation_id"`
+	ResponseTopic string `json:"response_topic"`
+}
+
+type MqttCommandResult struct {
+	Command       string `json:"command"`
+	CorrelationID string `json:"correlation_id"`
+	Success       bool   `json:"success"`
+	ErrCode       int32  `json:"errcode"`
+	ErrMsg        string `json:"errmsg,omitempty"`
+	DurationMs    int64  `json:"duration_ms"`
+}
+
+func parseLockCommand(payload []byte) (LockCommand, error) {
+	cmd := mqttLockCommand{Command: strings.TrimSpace(string(payload))}
+
+	if strings.HasPrefix(cmd.Command, "{") {
+		if err := json.Unmarshal(payload, &cmd); err != nil {
+			return LockCommand{}, fmt.Errorf("invalid command payload: %w", err)
+		}
+	}
+
+	lc := LockCommand{
+		Name:          strings.ToUpper(cmd.Command),
+		CorrelationID: cmd.CorrelationID,
+		ResponseTopic: cmd.ResponseTopic,
+	}
+
+	switch lc.Name {
+	case "LOCK":
+		lc.Status = ttlock.Locked
+	case "UNLOCK":
+		lc.Status = ttlock.Unlocked
+	default:
+		return lc, fmt.Errorf("unsupported command: %s", cmd.Command)
+	}
+
+	if lc.CorrelationID == "" {
+		id := make([]byte, 8)
+		if _, err := rand.Read(id); err == nil {
+			lc.CorrelationID = hex.EncodeToString(id)
+		}
+	}
+
+	return lc, nil
+}
+
+// PublishCommandResult reports the outcome of a lock command on the lock result topic and
+// on the response topic requested by the command
+func (m *HAMqtt) PublishCommandResult(l locks.ManagedLock, cmd LockCommand, duration time.Duration, cmdErr error) error {
+	result := MqttCommandResult{
+		Command:       cmd.Name,
+		CorrelationID: cmd.CorrelationID,
+		Success:       cmdErr == nil,
+		DurationMs:    duration.Milliseconds(),
+	}
+
+	if cmdErr != nil {
+		result.ErrMsg = cmdErr.Error()
+
+		var apiErr *ttlock.APIError
+		if errors.As(cmdErr, &apiErr) {
+			result.ErrCode = apiErr.Code
+			result.ErrMsg = apiErr.Message
+		}
+	}
+
+	payload, err := json.Marshal(result)
+
+	if err != nil {
+		return fmt.Errorf("could not serialize command result: %w", err)
+	}
+
+	if err := m.publish(m.lockTopic(l, "command_result"), false, string(payload)); err != nil {
+		return err
+	}
+
+	if cmd.ResponseTopic != "" && cmd.ResponseTopic != m.lockTopic(l, "command_result") {
+		return m.publish(cmd.ResponseTopic, false, string(payload))
+	}
+
+	return nil
+}
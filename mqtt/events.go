@@ -0,0 +1,116 @@
+package mqtt
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/nikolai5slo/ttlock2mqtt/locks"
+	"github.com/nikolai5slo/ttlock2mqtt/ttlock"
+)
+
+var lockEventTypes = []string{"unlock", "lock", "failed", "other"}
+
+type MqttEventConfig struct {
+	StateTopic       string             `json:"state_topic"`
+	Name             string             `json:"name"`
+	UniqueID         string             `json:"unique_id"`
+	EventTypes       []string           `json:"event_types"`
+	Device           MqttDevice         `json:"device"`
+	Availability     []MqttAvailability `json:"availability"`
+	AvailabilityMode string             `json:"availability_mode"`
+}
+
+type MqttDeviceTriggerConfig struct {
+	AutomationType string     `json:"automation_type"`
+	Topic          string     `json:"topic"`
+	Type           string     `json:"type"`
+	Subtype        string     `json:"subtype"`
+	Payload        string     `json:"payload"`
+	ValueTemplate  string     `json:"value_template"`
+	Device         MqttDevice `json:"device"`
+}
+
+type MqttLockEvent struct {
+	EventType  string    `json:"event_type"`
+	RecordID   int64     `json:"record_id,omitempty"`
+	RecordType int32     `json:"record_type"`
+	Method     string    `json:"method"`
+	Username   string    `json:"username,omitempty"`
+	Success    bool      `json:"success"`
+	Timestamp  time.Time `json:"timestamp"`
+}
+
+func (m *HAMqtt) introduceEvents(l locks.ManagedLock) error {
+	eventTopic := m.lockTopic(l, "event")
+
+	event := &MqttEventConfig{
+		StateTopic:       eventTopic,
+		Name:             fmt.Sprintf("%s Activity", l.LockAlias),
+		UniqueID:         fmt.Sprintf("%d_activity", l.LockId),
+		EventTypes:       lockEventTypes,
+		Device:           m.lockDevice(l),
+		Availability:     m.lockAvailability(l),
+		AvailabilityMode: "all",
+	}
+
+	configTopics := m.eventConfigTopics(l)
+
+	err := m.publishConfig(configTopics[0], event)
+
+	if err != nil {
+		return err
+	}
+
+	for i, t := range lockEventTypes {
+		trigger := &MqttDeviceTriggerConfig{
+			AutomationType: "trigger",
+			Topic:          eventTopic,
+			Type:           t,
+			Subtype:        "lock",
+			Payload:        t,
+			ValueTemplate:  "{{ value_json.event_type }}",
+			Device:         m.lockDevice(l),
+		}
+
+		err := m.publishConfig(configTopics[i+1], trigger)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// eventConfigTopics returns the event entity topic followed by device trigger topics
+func (m *HAMqtt) eventConfigTopics(l locks.ManagedLock) []string {
+	topics := []string{m.discoveryTopic("event", fmt.Sprintf("%d_activity", l.LockId))}
+
+	for _, t := range lockEventTypes {
+		topics = append(topics, m.discoveryTopic("device_automation", fmt.Sprintf("%d_%s", l.LockId, t)))
+	}
+
+	return topics
+}
+
+// PublishLockEvent publishes a lock record on the lock event topic
+func (m *HAMqtt) PublishLockEvent(l locks.ManagedLock, r ttlock.Record) error {
+	event := &MqttLockEvent{
+		EventType:  r.EventType(),
+		RecordID:   r.RecordId,
+		RecordType: r.RecordType,
+		Method:     r.Method(),
+		Username:   r.Username,
+		Success:    r.Succeeded(),
+		Timestamp:  r.Time(),
+	}
+
+	payload, err := json.Marshal(event)
+
+	if err != nil {
+		return fmt.Errorf("could not serialize lock event: %w", err)
+	}
+
+	return m.publish(m.lockTopic(l, "event"), false, string(payload))
+}
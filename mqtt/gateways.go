@@ -0,0 +1,138 @@
+package mqtt
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/nikolai5slo/ttlock2mqtt/ttlock"
+)
+
+func gatewayIdentifier(gatewayID int32) string {
+	return fmt.Sprintf("gateway_%d", gatewayID)
+}
+
+func (m *HAMqtt) gatewayTopic(gatewayID int32, name string) string {
+	return fmt.Sprintf("%s/gateway/%d/%s", m.baseTopic, gatewayID, name)
+}
+
+func (m *HAMqtt) gatewayConfigTopics(gatewayID int32) []string {
+	return []string{
+		m.discoveryTopic("binary_sensor", fmt.Sprintf("%s_online", gatewayIdentifier(gatewayID))),
+		m.discoveryTopic("sensor", fmt.Sprintf("%s_locks", gatewayIdentifier(gatewayID))),
+	}
+}
+
+func gatewayDevice(g ttlock.Gateway) MqttDevice {
+	device := MqttDevice{
+		Name:         fmt.Sprintf("Gateway %d", g.GatewayId),
+		Model:        "Gateway",
+		Manufacturer: "TTLock",
+		Identifiers:  []string{gatewayIdentifier(g.GatewayId)},
+	}
+
+	if g.GatewayName != nil && *g.GatewayName != "" {
+		device.Name = *g.GatewayName
+	}
+
+	if g.GatewayVersion != nil {
+		device.Model = fmt.Sprintf("G%d", *g.GatewayVersion)
+	}
+
+	if g.GatewayMac != nil {
+		device.Connections = [][2]string{{"mac", strings.ToLower(*g.GatewayMac)}}
+	}
+
+	return device
+}
+
+// IntroduceGateway announces the gateway as a device with connectivity and lock count sensors
+func (m *HAMqtt) IntroduceGateway(g ttlock.Gateway) error {
+	device := gatewayDevice(g)
+	availability := []MqttAvailability{
+		{
+			Topic:               m.bridgeAvailabilityTopic(),
+			PayloadAvailable:    payloadOnline,
+			PayloadNotAvailable: payloadOffline,
+		},
+	}
+
+	configTopics := m.gatewayConfigTopics(g.GatewayId)
+
+	online := &MqttSensorConfig{
+		StateTopic:       m.gatewayTopic(g.GatewayId, "online"),
+		Name:             fmt.Sprintf("%s Online", device.Name),
+		UniqueID:         fmt.Sprintf("%s_online", gatewayIdentifier(g.GatewayId)),
+		DeviceClass:      "connectivity",
+		EntityCategory:   "diagnostic",
+		PayloadOn:        "ON",
+		PayloadOff:       "OFF",
+		Device:           device,
+		Availability:     availability,
+		AvailabilityMode: "all",
+	}
+
+	if err := m.publishConfig(configTopics[0], online); err != nil {
+		return err
+	}
+
+	lockCount := &MqttSensorConfig{
+		StateTopic:       m.gatewayTopic(g.GatewayId, "locks"),
+		Name:             fmt.Sprintf("%s Locks", device.Name),
+		UniqueID:         fmt.Sprintf("%s_locks", gatewayIdentifier(g.GatewayId)),
+		StateClass:       "measurement",
+		EntityCategory:   "diagnostic",
+		Device:           device,
+		Availability:     availability,
+		AvailabilityMode: "all",
+	}
+
+	return m.publishConfig(configTopics[1], lockCount)
+}
+
+// UpdateGateway publishes the gateway connectivity and number of bound locks
+func (m *HAMqtt) UpdateGateway(g ttlock.Gateway) error {
+	online := "OFF"
+	if g.IsOnline != nil && *g.IsOnline == 1 {
+		online = "ON"
+	}
+
+	if err := m.publish(m.gatewayTopic(g.GatewayId, "online"), true, online); err != nil {
+		return err
+	}
+
+	if g.LockNum == nil {
+		return nil
+	}
+
+	return m.publish(m.gatewayTopic(g.GatewayId, "locks"), true, fmt.Sprint(*g.LockNum))
+}
+
+// RetireGateway removes the gateway from Home Assistant
+func (m *HAMqtt) RetireGateway(gatewayID int32) error {
+	topics := m.gatewayConfigTopics(gatewayID)
+	topics = append(topics, m.gatewayTopic(gatewayID, "online"), m.gatewayTopic(gatewayID, "locks"))
+
+	return m.clearRetained(topics)
+}
+
+// SetLockGateway sets the gateway the lock device is connected via, zero clears it.
+// The lock has to be introduced again for Home Assistant to pick it up.
+func (m *HAMqtt) SetLockGateway(lockID int32, gatewayID int32) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if gatewayID == 0 {
+		delete(m.lockGateways, lockID)
+		return
+	}
+
+	m.lockGateways[lockID] = gatewayID
+}
+
+// LockGateway returns the gateway the lock device is connected via, zero if none
+func (m *HAMqtt) LockGateway(lockID int32) int32 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.lockGateways[lockID]
+}
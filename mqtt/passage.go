@@ -0,0 +1,354 @@
+package mqtt
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+	"github.com/nikolai5slo/ttlock2mqtt/locks"
+	"github.com/nikolai5slo/ttlock2mqtt/ttlock"
+)
+
+// Passage mode values, each has a state topic and a /set command topic
+var passageModeValues = []string{"enabled", "days", "start", "end", "config"}
+
+const passageModeCustomDays = "Custom"
+
+var passageModeDays = []struct {
+	name     string
+	weekdays []int32
+}{
+	{"Every day", []int32{1, 2, 3, 4, 5, 6, 7}},
+	{"Weekdays", []int32{1, 2, 3, 4, 5}},
+	{"Weekend", []int32{6, 7}},
+}
+
+const passageModeTimePattern = "^([01][0-9]|2[0-3]):[0-5][0-9]$"
+
+// MqttControlConfig configures switch, select and text entities
+type MqttControlConfig struct {
+	CommandTopic     string             `json:"command_topic"`
+	StateTopic       string             `json:"state_topic"`
+	Name             string             `json:"name"`
+	UniqueID         string             `json:"unique_id"`
+	Icon             string             `json:"icon,omitempty"`
+	EntityCategory   string             `json:"entity_category,omitempty"`
+	PayloadOn        string             `json:"payload_on,omitempty"`
+	PayloadOff       string             `json:"payload_off,omitempty"`
+	Options          []string           `json:"options,omitempty"`
+	Pattern          string             `json:"pattern,omitempty"`
+	Device           MqttDevice         `json:"device"`
+	Availability     []MqttAvailability `json:"availability"`
+	AvailabilityMode string             `json:"availability_mode"`
+}
+
+// MqttPassageMode is the passage mode configuration as published on the config topic
+type MqttPassageMode struct {
+	Enabled    bool    `json:"enabled"`
+	AllDay     bool    `json:"all_day"`
+	AutoUnlock bool    `json:"auto_unlock"`
+	Start      string  `json:"start"`
+	End        string  `json:"end"`
+	Weekdays   []int32 `json:"weekdays"`
+}
+
+// PassageModeUpdate changes passage mode configuration, unset fields are kept
+type PassageModeUpdate struct {
+	Enabled       *bool   `json:"enabled"`
+	AllDay        *bool   `json:"all_day"`
+	AutoUnlock    *bool   `json:"auto_unlock"`
+	Start         *string `json:"start"`
+	End           *string `json:"end"`
+	Weekdays      []int32 `json:"weekdays"`
+	CorrelationID string  `json:"correlation_id"`
+	ResponseTopic string  `json:"response_topic"`
+}
+
+// Apply returns the configuration with the update applied
+func (u PassageModeUpdate) Apply(p ttlock.PassageMode) (ttlock.PassageMode, error) {
+	if u.Enabled != nil {
+		p.Enabled = *u.Enabled
+	}
+	if u.AllDay != nil {
+		p.AllDay = *u.AllDay
+	}
+	if u.AutoUnlock != nil {
+		p.AutoUnlock = *u.AutoUnlock
+	}
+
+	if u.Start != nil {
+		start, err := parseMinutes(*u.Start)
+
+		if err != nil {
+			return p, fmt.Errorf("invalid start: %w", err)
+		}
+
+		p.Start = start
+	}
+
+	if u.End != nil {
+		end, err := parseMinutes(*u.End)
+
+		if err != nil {
+			return p, fmt.Errorf("invalid end: %w", err)
+		}
+
+		p.End = end
+	}
+
+	if u.Weekdays != nil {
+		for _, d := range u.Weekdays {
+			if d < 1 || d > 7 {
+				return p, fmt.Errorf("invalid weekday: %d", d)
+			}
+		}
+
+		p.Weekdays = u.Weekdays
+	}
+
+	if p.Enabled && len(p.Weekdays) == 0 {
+		return p, fmt.Errorf("passage mode needs at least one weekday")
+	}
+
+	if p.Enabled && !p.AllDay && p.Start >= p.End {
+		return p, fmt.Errorf("passage mode has to start before it ends")
+	}
+
+	return p, nil
+}
+
+func parseMinutes(s string) (int32, error) {
+	t, err := time.Parse("15:04", strings.TrimSpace(s))
+
+	if err != nil {
+		return 0, err
+	}
+
+	return int32(t.Hour()*60 + t.Minute()), nil
+}
+
+func formatMinutes(minutes int32) string {
+	return fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
+}
+
+func weekdaysName(weekdays []int32) string {
+	for _, d := range passageModeDays {
+		if sameWeekdays(d.weekdays, weekdays) {
+			return d.name
+		}
+	}
+
+	return passageModeCustomDays
+}
+
+func sameWeekdays(a []int32, b []int32) bool {
+	set := map[int32]bool{}
+	for _, d := range a {
+		set[d] = true
+	}
+
+	other := map[int32]bool{}
+	for _, d := range b {
+		if !set[d] {
+			return false
+		}
+		other[d] = true
+	}
+
+	return len(set) == len(other)
+}
+
+func parsePassageModeCommand(value string, payload []byte) (PassageModeUpdate, error) {
+	u := PassageModeUpdate{}
+	text := strings.TrimSpace(string(payload))
+
+	switch value {
+	case "enabled":
+		switch strings.ToUpper(text) {
+		case "ON":
+			u.Enabled = boolPtr(true)
+		case "OFF":
+			u.Enabled = boolPtr(false)
+		default:
+			return u, fmt.Errorf("unsupported passage mode command: %s", text)
+		}
+	case "days":
+		for _, d := range passageModeDays {
+			if d.name == text {
+				u.Weekdays = d.weekdays
+				return u, nil
+			}
+		}
+
+		return u, fmt.Errorf("unsupported passage mode days: %s, custom days are set on the config topic", text)
+	case "start":
+		u.Start = &text
+		u.AllDay = boolPtr(false)
+	case "end":
+		u.End = &text
+		u.AllDay = boolPtr(false)
+	case "config":
+		if err := json.Unmarshal(payload, &u); err != nil {
+			return u, fmt.Errorf("invalid passage mode config: %w", err)
+		}
+	}
+
+	return u, nil
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
+
+func (m *HAMqtt) passageModeTopic(l locks.ManagedLock, value string) string {
+	return m.lockTopic(l, "passage_mode/"+value)
+}
+
+func (m *HAMqtt) passageModeConfigTopics(l locks.ManagedLock) []string {
+	return []string{
+		m.discoveryTopic("switch", fmt.Sprintf("%d_passage_mode", l.LockId)),
+		m.discoveryTopic("select", fmt.Sprintf("%d_passage_mode_days", l.LockId)),
+		m.discoveryTopic("text", fmt.Sprintf("%d_passage_mode_start", l.LockId)),
+		m.discoveryTopic("text", fmt.Sprintf("%d_passage_mode_end", l.LockId)),
+	}
+}
+
+func (m *HAMqtt) introducePassageMode(l locks.ManagedLock) error {
+	days := []string{}
+	for _, d := range passageModeDays {
+		days = append(days, d.name)
+	}
+	days = append(days, passageModeCustomDays)
+
+	configs := []*MqttControlConfig{
+		{
+			Name:       fmt.Sprintf("%s Passage Mode", l.LockAlias),
+			UniqueID:   fmt.Sprintf("%d_passage_mode", l.LockId),
+			Icon:       "mdi:door-open",
+			PayloadOn:  "ON",
+			PayloadOff: "OFF",
+		},
+		{
+			Name:           fmt.Sprintf("%s Passage Mode Days", l.LockAlias),
+			UniqueID:       fmt.Sprintf("%d_passage_mode_days", l.LockId),
+			Icon:           "mdi:calendar-week",
+			EntityCategory: "config",
+			Options:        days,
+		},
+		{
+			Name:           fmt.Sprintf("%s Passage Mode Start", l.LockAlias),
+			UniqueID:       fmt.Sprintf("%d_passage_mode_start", l.LockId),
+			Icon:           "mdi:clock-start",
+			EntityCategory: "config",
+			Pattern:        passageModeTimePattern,
+		},
+		{
+			Name:           fmt.Sprintf("%s Passage Mode End", l.LockAlias),
+			UniqueID:       fmt.Sprintf("%d_passage_mode_end", l.LockId),
+			Icon:           "mdi:clock-end",
+			EntityCategory: "config",
+			Pattern:        passageModeTimePattern,
+		},
+	}
+
+	configTopics := m.passageModeConfigTopics(l)
+
+	for i, config := range configs {
+		config.StateTopic = m.passageModeTopic(l, passageModeValues[i])
+		config.CommandTopic = m.passageModeTopic(l, passageModeValues[i]+"/set")
+		config.Device = m.lockDevice(l)
+		config.Availability = m.lockAvailability(l)
+		config.AvailabilityMode = "all"
+
+		if err := m.publishConfig(configTopics[i], config); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// MqttPassageModeCallback listens for passage mode changes of the switch, select and text
+// entities and for JSON updates on the config topic
+func (m *HAMqtt) MqttPassageModeCallback(l locks.ManagedLock, callback func(LockCommand, PassageModeUpdate)) error {
+	for _, value := range passageModeValues {
+		value := value
+
+		err := m.subscribe(m.passageModeTopic(l, value+"/set"), func(c mqtt.Client, msg mqtt.Message) {
+			update, err := parsePassageModeCommand(value, msg.Payload())
+			cmd := LockCommand{
+				Status:        ttlock.Unknown,
+				Name:          "PASSAGE_MODE",
+				CorrelationID: update.CorrelationID,
+				ResponseTopic: update.ResponseTopic,
+			}
+
+			if err != nil {
+				log.Printf("ignoring passage mode command for lock %d: %s", l.LockId, err)
+
+				if err := m.PublishCommandResult(l, cmd, 0, err); err != nil {
+					log.Printf("failed to publish command result: %s", err)
+				}
+				return
+			}
+
+			callback(cmd, update)
+		})
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// UpdatePassageMode publishes the passage mode configuration to the entities and the config topic
+func (m *HAMqtt) UpdatePassageMode(l locks.ManagedLock, p ttlock.PassageMode) error {
+	config := MqttPassageMode{
+		Enabled:    p.Enabled,
+		AllDay:     p.AllDay,
+		AutoUnlock: p.AutoUnlock,
+		Start:      formatMinutes(p.Start),
+		End:        formatMinutes(p.End),
+		Weekdays:   p.Weekdays,
+	}
+
+	payload, err := json.Marshal(config)
+
+	if err != nil {
+		return fmt.Errorf("could not serialize passage mode: %w", err)
+	}
+
+	enabled := "OFF"
+	if p.Enabled {
+		enabled = "ON"
+	}
+
+	values := []string{enabled, weekdaysName(p.Weekdays), config.Start, config.End, string(payload)}
+
+	for i, value := range values {
+		if err := m.publish(m.passageModeTopic(l, passageModeValues[i]), true, value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *HAMqtt) releasePassageModeTopics(l locks.ManagedLock) error {
+	for _, value := range passageModeValues {
+		if err := m.unsubscribe(m.passageModeTopic(l, value+"/set")); err != nil {
+			return fmt.Errorf("cannot unsubscribe passage mode commands: %w", err)
+		}
+
+		if err := m.publish(m.passageModeTopic(l, value), true, ""); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,177 @@
+package mqtt
+
+import (
+	"errors"
+	"log"
+
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+)
+
+// Messages published while disconnected are kept up to this limit, oldest are dropped first
+const maxQueuedMessages = 1000
+
+type queuedMessage struct {
+	topic    string
+	retained bool
+	payload  string
+}
+
+// WithPersistentSession keeps subscriptions and QoS 1 messages on the broker while disconnected,
+// it requires a client id unique to this bridge
+func WithPersistentSession(persistent bool) Conf {
+	return func(h *HAMqtt) error {
+		h.opts.SetCleanSession(!persistent)
+		return nil
+	}
+}
+
+// SetConnectionHandler sets the function notified when the broker connection is established or lost
+func (m *HAMqtt) SetConnectionHandler(f func(connected bool)) {
+	m.onConnection = f
+}
+
+// Connected reports whether the broker connection is currently open
+func (m *HAMqtt) Connected() bool {
+	return m.client.IsConnectionOpen()
+}
+
+func (m *HAMqtt) onConnect(c mqtt.Client) {
+	log.Printf("connected to mqtt broker")
+
+	c.Publish(m.bridgeAvailabilityTopic(), 1, true, payloadOnline)
+
+	// Clean sessions lose subscriptions, restore them on every connect
+	m.mu.Lock()
+	filters := make(map[string]byte, len(m.subscriptions))
+	for topic := range m.subscriptions {
+		filters[topic] = 1
+	}
+	m.mu.Unlock()
+
+	if len(filters) > 0 {
+		token := c.SubscribeMultiple(filters, m.route)
+		if token.WaitTimeout(m.timeout) && token.Error() != nil {
+			log.Printf("failed to restore subscriptions: %s", token.Error())
+		}
+	}
+
+	m.flush()
+
+	if m.onConnection != nil {
+		m.onConnection(true)
+	}
+
+	// Broker may have lost retained messages
+	m.triggerReannounce()
+}
+
+func (m *HAMqtt) onConnectionLost(c mqtt.Client, err error) {
+	log.Printf("lost connection to mqtt broker: %s", err)
+
+	if m.onConnection != nil {
+		m.onConnection(false)
+	}
+}
+
+// route dispatches messages of restored subscriptions to their handlers
+func (m *HAMqtt) route(c mqtt.Client, msg mqtt.Message) {
+	m.mu.Lock()
+	handler, ok := m.subscriptions[msg.Topic()]
+	m.mu.Unlock()
+
+	if ok {
+		handler(c, msg)
+	}
+}
+
+// subscribe remembers the subscription so it survives reconnects
+func (m *HAMqtt) subscribe(topic string, handler mqtt.MessageHandler) error {
+	m.mu.Lock()
+	m.subscriptions[topic] = handler
+	m.mu.Unlock()
+
+	// Subscribed on connect
+	if !m.Connected() {
+		return nil
+	}
+
+	token := m.client.Subscribe(topic, 1, handler)
+
+	token.WaitTimeout(m.timeout)
+
+	return token.Error()
+}
+
+func (m *HAMqtt) unsubscribe(topic string) error {
+	m.mu.Lock()
+	delete(m.subscriptions, topic)
+	m.mu.Unlock()
+
+	if !m.Connected() {
+		return nil
+	}
+
+	token := m.client.Unsubscribe(topic)
+
+	token.WaitTimeout(m.timeout)
+
+	return token.Error()
+}
+
+// publish sends the message, or queues it until the connection is back
+func (m *HAMqtt) publish(topic string, retained bool, payload string) error {
+	if !m.Connected() {
+		m.enqueue(queuedMessage{topic: topic, retained: retained, payload: payload})
+		return nil
+	}
+
+	token := m.client.Publish(topic, 1, retained, payload)
+
+	token.WaitTimeout(m.timeout)
+
+	if errors.Is(token.Error(), mqtt.ErrNotConnected) {
+		m.enqueue(queuedMessage{topic: topic, retained: retained, payload: payload})
+		return nil
+	}
+
+	return token.Error()
+}
+
+func (m *HAMqtt) enqueue(msg queuedMessage) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// Only the latest retained value of a topic matters
+	if msg.retained {
+		for i, q := range m.queue {
+			if q.retained && q.topic == msg.topic {
+				m.queue = append(m.queue[:i], m.queue[i+1:]...)
+				break
+			}
+		}
+	}
+
+	if len(m.queue) >= maxQueuedMessages {
+		log.Printf("mqtt queue full, dropping message for %s", m.queue[0].topic)
+		m.queue = m.queue[1:]
+	}
+
+	m.queue = append(m.queue, msg)
+}
+
+func (m *HAMqtt) flush() {
+	m.mu.Lock()
+	queue := m.queue
+	m.queue = nil
+	m.mu.Unlock()
+
+	if len(queue) > 0 {
+		log.Printf("publishing %d messages queued while disconnected", len(queue))
+	}
+
+	for _, msg := range queue {
+		if err := m.publish(msg.topic, msg.retained, msg.payload); err != nil {
+			log.Printf("failed to publish queued message for %s: %s", msg.topic, err)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package mqtt
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+)
+
+// WithCACert trusts the CA certificates in the PEM file instead of the system roots
+func WithCACert(path string) Conf {
+	return func(h *HAMqtt) error {
+		if path == "" {
+			return nil
+		}
+
+		pem, err := os.ReadFile(path)
+
+		if err != nil {
+			return fmt.Errorf("cannot read CA certificate: %w", err)
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return fmt.Errorf("no certificates found in %s", path)
+		}
+
+		h.tls().RootCAs = pool
+		return nil
+	}
+}
+
+// WithClientCertificate authenticates to the broker with a client certificate
+func WithClientCertificate(certPath string, keyPath string) Conf {
+	return func(h *HAMqtt) error {
+		if certPath == "" && keyPath == "" {
+			return nil
+		}
+
+		cert, err := tls.LoadX509KeyPair(certPath, keyPath)
+
+		if err != nil {
+			return fmt.Errorf("cannot load client certificate: %w", err)
+		}
+
+		h.tls().Certificates = []tls.Certificate{cert}
+		return nil
+	}
+}
+
+// WithInsecureSkipVerify disables broker certificate verification, use only for testing
+func WithInsecureSkipVerify(skip bool) Conf {
+	return func(h *HAMqtt) error {
+		if skip {
+			h.tls().InsecureSkipVerify = true
+		}
+		return nil
+	}
+}
+
+// WithServerName overrides the name used for SNI and certificate verification
+func WithServerName(name string) Conf {
+	return func(h *HAMqtt) error {
+		if name != "" {
+			h.tls().ServerName = name
+		}
+		return nil
+	}
+}
+
+// WithALPN sets the protocols offered in TLS ALPN, e.g. x-amzn-mqtt-ca
+func WithALPN(protocols ...string) Conf {
+	return func(h *HAMqtt) error {
+		for _, p := range protocols {
+			if p != "" {
+				h.tls().NextProtos = append(h.tls().NextProtos, p)
+			}
+		}
+		return nil
+	}
+}
+
+func (h *HAMqtt) tls() *tls.Config {
+	if h.tlsConfig == nil {
+		h.tlsConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+
+	return h.tlsConfig
+}
@@ -0,0 +1,181 @@
+package mqtt
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/nikolai5slo/ttlock2mqtt/locks"
+)
+
+// WithBaseTopic sets the prefix of the bridge state and command topics
+func WithBaseTopic(topic string) Conf {
+	return func(h *HAMqtt) error {
+		topic, err := cleanTopic(topic)
+
+		if err != nil {
+			return fmt.Errorf("invalid base topic: %w", err)
+		}
+
+		h.baseTopic = topic
+		return nil
+	}
+}
+
+// WithDiscoveryPrefix sets the Home Assistant discovery prefix
+func WithDiscoveryPrefix(prefix string) Conf {
+	return func(h *HAMqtt) error {
+		prefix, err := cleanTopic(prefix)
+
+		if err != nil {
+			return fmt.Errorf("invalid discovery prefix: %w", err)
+		}
+
+		h.discoveryPrefix = prefix
+		return nil
+	}
+}
+
+// WithNodeID sets the node id of discovery topics, bridges sharing a broker need different node ids
+func WithNodeID(nodeID string) Conf {
+	return func(h *HAMqtt) error {
+		if nodeID == "" || strings.ContainsAny(nodeID, "/+#") {
+			return fmt.Errorf("invalid node id: %q", nodeID)
+		}
+
+		h.nodeID = nodeID
+		return nil
+	}
+}
+
+// WithAliasTopics keys lock topics by sanitized lock alias instead of lock id
+func WithAliasTopics(enabled bool) Conf {
+	return func(h *HAMqtt) error {
+		h.aliasTopics = enabled
+		return nil
+	}
+}
+
+func cleanTopic(topic string) (string, error) {
+	topic = strings.Trim(topic, "/")
+
+	if topic == "" {
+		return "", fmt.Errorf("topic is empty")
+	}
+
+	if strings.ContainsAny(topic, "+#") {
+		return "", fmt.Errorf("topic %q contains wildcards", topic)
+	}
+
+	return topic, nil
+}
+
+func (m *HAMqtt) bridgeAvailabilityTopic() string {
+	return m.baseTopic + "/availability"
+}
+
+// lockTopic returns the topic of a lock value, e.g. <base>/<lock>/state
+func (m *HAMqtt) lockTopic(l locks.ManagedLock, name string) string {
+	return fmt.Sprintf("%s/%s/%s", m.baseTopic, m.lockKey(l), name)
+}
+
+// discoveryTopic returns the Home Assistant config topic of an entity
+func (m *HAMqtt) discoveryTopic(component string, objectID string) string {
+	return fmt.Sprintf("%s/%s/%s/%s/config", m.discoveryPrefix, component, m.nodeID, objectID)
+}
+
+func (m *HAMqtt) lockKey(l locks.ManagedLock) string {
+	m.keysMu.RLock()
+	collides := m.aliasCollisions[l.LockId]
+	m.keysMu.RUnlock()
+
+	if m.aliasTopics && !collides {
+		if alias := sanitizeTopicLevel(l.LockAlias); alias != "" {
+			return alias
+		}
+	}
+
+	return fmt.Sprint(l.LockId)
+}
+
+// AliasCollisions returns the locks whose alias key is shared with another lock or topic,
+// they fall back to lock id topics
+func (m *HAMqtt) AliasCollisions(ls locks.LockList) map[int32]bool {
+	collisions := map[int32]bool{}
+
+	if !m.aliasTopics {
+		return collisions
+	}
+
+	byKey := map[string][]int32{}
+	for _, l := range ls {
+		if key := sanitizeTopicLevel(l.LockAlias); key != "" {
+			byKey[key] = append(byKey[key], l.LockId)
+		}
+	}
+
+	// Lock id topics of other locks and gateway topics are taken as well
+	taken := map[string]bool{"gateway": true}
+	for _, l := range ls {
+		taken[fmt.Sprint(l.LockId)] = true
+	}
+
+	for key, ids := range byKey {
+		if len(ids) == 1 && (!taken[key] || key == fmt.Sprint(ids[0])) {
+			continue
+		}
+
+		for _, id := range ids {
+			collisions[id] = true
+		}
+	}
+
+	return collisions
+}
+
+// SetAliasCollisions switches colliding locks to lock id topics. Topics of introduced locks whose
+// key changes have to be released before and announced again after.
+func (m *HAMqtt) SetAliasCollisions(collisions map[int32]bool) {
+	m.keysMu.Lock()
+	defer m.keysMu.Unlock()
+
+	for id := range collisions {
+		if !m.aliasCollisions[id] {
+			log.Printf("warning: alias of lock %d collides with another lock topic, using the lock id", id)
+		}
+	}
+
+	m.aliasCollisions = collisions
+}
+
+// AliasCollides reports whether the lock currently falls back to lock id topics
+func (m *HAMqtt) AliasCollides(lockID int32) bool {
+	m.keysMu.RLock()
+	defer m.keysMu.RUnlock()
+
+	return m.aliasCollisions[lockID]
+}
+
+// sanitizeTopicLevel lowercases s and replaces everything but letters, digits, - and _ with _
+func sanitizeTopicLevel(s string) string {
+	var b strings.Builder
+
+	underscore := false
+
+	for _, r := range strings.ToLower(strings.TrimSpace(s)) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '-' {
+			b.WriteRune(r)
+			underscore = false
+		} else if !underscore {
+			b.WriteRune('_')
+			underscore = true
+		}
+	}
+
+	return strings.Trim(b.String(), "_")
+}
+
+// SameTopics reports whether both versions of a lock use the same topics
+func (m *HAMqtt) SameTopics(a locks.ManagedLock, b locks.ManagedLock) bool {
+	return m.lockKey(a) == m.lockKey(b)
+}
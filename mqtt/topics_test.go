@@ -0,0 +1,74 @@
+package mqtt
+
+import (
+	"testing"
+
+	"github.com/nikolai5slo/ttlock2mqtt/locks"
+	"github.com/nikolai5slo/ttlock2mqtt/ttlock"
+)
+
+func managedLock(id int32, alias string) locks.ManagedLock {
+	return locks.ManagedLock{Lock: ttlock.Lock{LockId: id, LockAlias: alias}}
+}
+
+func TestLockKey(t *testing.T) {
+	tests := []struct {
+		name        string
+		aliasTopics bool
+		managed     locks.LockList
+		lock        locks.ManagedLock
+		want        string
+	}{
+		{"lock id topics", false, nil, managedLock(1, "Front Door"), "1"},
+		{"alias", true, nil, managedLock(1, "Front Door"), "front_door"},
+		{"alias sanitized", true, nil, managedLock(1, " Garage/Door #2 "), "garage_door_2"},
+		{"empty alias", true, nil, managedLock(1, "!!"), "1"},
+		{
+			"unique alias",
+			true,
+			locks.LockList{managedLock(1, "Front Door"), managedLock(2, "Back Door")},
+			managedLock(1, "Front Door"),
+			"front_door",
+		},
+		{
+			"dash kept apart from space",
+			true,
+			locks.LockList{managedLock(1, "Front Door"), managedLock(2, "front-door")},
+			managedLock(1, "Front Door"),
+			"front_door",
+		},
+		{
+			"aliases sanitized to the same key",
+			true,
+			locks.LockList{managedLock(1, "Front Door"), managedLock(2, "front  door!")},
+			managedLock(2, "front  door!"),
+			"2",
+		},
+		{
+			"alias of another lock id",
+			true,
+			locks.LockList{managedLock(1, "2"), managedLock(2, "Back Door")},
+			managedLock(1, "2"),
+			"1",
+		},
+		{
+			"alias of own lock id",
+			true,
+			locks.LockList{managedLock(1, "1")},
+			managedLock(1, "1"),
+			"1",
+		},
+		{"gateway topics", true, locks.LockList{managedLock(1, "Gateway")}, managedLock(1, "Gateway"), "1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &HAMqtt{aliasTopics: tt.aliasTopics}
+			m.SetAliasCollisions(m.AliasCollisions(tt.managed))
+
+			if got := m.lockKey(tt.lock); got != tt.want {
+				t.Errorf("lockKey() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
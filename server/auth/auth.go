@@ -0,0 +1,424 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	// Context keys set by the middleware for authenticated requests
+	ContextUser = "auth_user"
+	ContextCSRF = "auth_csrf"
+
+	CSRFFormField = "_csrf"
+	CSRFHeader    = "X-CSRF-Token"
+
+	sessionCookie = "ttlock2mqtt_session"
+	loginCookie   = "ttlock2mqtt_login"
+)
+
+var (
+	ErrInvalidLogin    = errors.New("invalid username or password")
+	ErrTooManyAttempts = errors.New("too many failed logins, try again later")
+)
+
+const minPasswordLength = 12
+
+type Auth struct {
+	username       string
+	passwordHash   []byte
+	secret         []byte
+	sessionTTL     time.Duration
+	proxyHeader    string
+	trustedProxies []*net.IPNet
+	throttle       *loginThrottle
+}
+
+type Conf func(*Auth) error
+
+func New(cfg ...Conf) (*Auth, error) {
+	a := &Auth{
+		sessionTTL: 24 * time.Hour,
+		throttle:   newLoginThrottle(),
+	}
+
+	for _, c := range cfg {
+		if err := c(a); err != nil {
+			return a, fmt.Errorf("auth configuration failed: %w", err)
+		}
+	}
+
+	if a.secret == nil {
+		a.secret = make([]byte, 32)
+		if _, err := rand.Read(a.secret); err != nil {
+			return a, fmt.Errorf("cannot generate session secret: %w", err)
+		}
+
+		if a.Enabled() {
+			log.Printf("no session secret configured, sessions will not survive restarts")
+		}
+	}
+
+	return a, nil
+}
+
+// WithCredentials sets the login, password is hashed before it is kept in memory
+func WithCredentials(username string, password string) Conf {
+	return func(a *Auth) error {
+		if username == "" || password == "" {
+			return nil
+		}
+
+		if len(password) < minPasswordLength {
+			return fmt.Errorf("password must be at least %d characters long", minPasswordLength)
+		}
+
+		hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+
+		if err != nil {
+			return err
+		}
+
+		a.username = username
+		a.passwordHash = hash
+		return nil
+	}
+}
+
+// WithPasswordHash sets the login with an already bcrypt hashed password
+func WithPasswordHash(username string, hash string) Conf {
+	return func(a *Auth) error {
+		if username == "" || hash == "" {
+			return nil
+		}
+
+		if _, err := bcrypt.Cost([]byte(hash)); err != nil {
+			return fmt.Errorf("invalid password hash: %w", err)
+		}
+
+		a.username = username
+		a.passwordHash = []byte(hash)
+		return nil
+	}
+}
+
+func WithSessionSecret(secret string) Conf {
+	return func(a *Auth) error {
+		if secret != "" {
+			a.secret = []byte(secret)
+		}
+		return nil
+	}
+}
+
+func WithSessionTTL(ttl time.Duration) Conf {
+	return func(a *Auth) error {
+		a.sessionTTL = ttl
+		return nil
+	}
+}
+
+// WithProxyHeader trusts the user name in header when request comes from one of the trusted proxies
+func WithProxyHeader(header string, trustedProxies []string) Conf {
+	return func(a *Auth) error {
+		if header == "" {
+			return nil
+		}
+
+		if len(trustedProxies) == 0 {
+			return fmt.Errorf("proxy header authentication requires trusted proxies")
+		}
+
+		for _, p := range trustedProxies {
+			if !strings.Contains(p, "/") {
+				if strings.Contains(p, ":") {
+					p += "/128"
+				} else {
+					p += "/32"
+				}
+			}
+
+			_, network, err := net.ParseCIDR(strings.TrimSpace(p))
+
+			if err != nil {
+				return fmt.Errorf("invalid trusted proxy: %w", err)
+			}
+
+			a.trustedProxies = append(a.trustedProxies, network)
+		}
+
+		a.proxyHeader = header
+		return nil
+	}
+}
+
+func (a *Auth) Enabled() bool {
+	return a.passwordHash != nil || a.proxyHeader != ""
+}
+
+// Middleware rejects unauthenticated requests and verifies CSRF tokens of unsafe requests
+func (a *Auth) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !a.Enabled() {
+			c.Next()
+			return
+		}
+
+		user, csrfToken, ok := a.proxyUser(c)
+
+		if !ok {
+			user, csrfToken, ok = a.sessionUser(c)
+		}
+
+		if !ok {
+			username, password, hasBasic := c.Request.BasicAuth()
+
+			if !hasBasic {
+				a.reject(c)
+				return
+			}
+
+			if err := a.checkPassword(c, username, password); err != nil {
+				if errors.Is(err, ErrTooManyAttempts) {
+					c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{"error": err.Error()})
+					return
+				}
+
+				a.reject(c)
+				return
+			}
+
+			// Browsers cache basic credentials and send them cross-site as well, unsafe requests
+			// need something a plain cross-site form cannot send
+			if !isSafeMethod(c.Request.Method) && !isScriptRequest(c) {
+				c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "unsafe requests need a JSON content type or an X-Requested-With header"})
+				return
+			}
+
+			c.Set(ContextUser, username)
+			c.Next()
+			return
+		}
+
+		c.Set(ContextUser, user)
+		c.Set(ContextCSRF, csrfToken)
+
+		if !isSafeMethod(c.Request.Method) {
+			token := c.GetHeader(CSRFHeader)
+			if token == "" {
+				token = c.PostForm(CSRFFormField)
+			}
+
+			if subtle.ConstantTimeCompare([]byte(token), []byte(csrfToken)) != 1 {
+				c.AbortWithStatus(http.StatusForbidden)
+				return
+			}
+		}
+
+		c.Next()
+	}
+}
+
+// LoginCSRF returns the CSRF token of the login form, bound to a nonce in a login cookie
+func (a *Auth) LoginCSRF(c *gin.Context) (string, error) {
+	nonce, err := c.Cookie(loginCookie)
+
+	if err != nil || nonce == "" {
+		raw := make([]byte, 16)
+		if _, err := rand.Read(raw); err != nil {
+			return "", err
+		}
+
+		nonce = hex.EncodeToString(raw)
+		c.SetSameSite(http.SameSiteStrictMode)
+		c.SetCookie(loginCookie, nonce, 0, "/login", "", a.secure(c), true)
+	}
+
+	return a.sign("login|" + nonce), nil
+}
+
+// CheckLoginCSRF verifies the CSRF token of a login form submission
+func (a *Auth) CheckLoginCSRF(c *gin.Context) bool {
+	nonce, err := c.Cookie(loginCookie)
+
+	if err != nil || nonce == "" {
+		return false
+	}
+
+	return subtle.ConstantTimeCompare([]byte(c.PostForm(CSRFFormField)), []byte(a.sign("login|"+nonce))) == 1
+}
+
+// Login verifies the password and starts a new session
+func (a *Auth) Login(c *gin.Context, username string, password string) error {
+	if err := a.checkPassword(c, username, password); err != nil {
+		return err
+	}
+
+	nonce := make([]byte, 16)
+	if _, err := rand.Read(nonce); err != nil {
+		return err
+	}
+
+	expires := time.Now().Add(a.sessionTTL)
+	payload := strings.Join([]string{hex.EncodeToString(nonce), username, strconv.FormatInt(expires.Unix(), 10)}, "|")
+	value := base64.RawURLEncoding.EncodeToString([]byte(payload)) + "." + a.sign(payload)
+
+	a.setCookie(c, value, int(a.sessionTTL.Seconds()))
+
+	// Login form is done with
+	c.SetCookie(loginCookie, "", -1, "/login", "", a.secure(c), true)
+
+	return nil
+}
+
+func (a *Auth) Logout(c *gin.Context) {
+	a.setCookie(c, "", -1)
+}
+
+// checkPassword verifies the login, clients with too many failed attempts are turned away before bcrypt runs
+func (a *Auth) checkPassword(c *gin.Context, username string, password string) error {
+	if a.passwordHash == nil {
+		return ErrInvalidLogin
+	}
+
+	client := c.ClientIP()
+
+	if !a.throttle.Allowed(client, time.Now()) {
+		return ErrTooManyAttempts
+	}
+
+	userOk := subtle.ConstantTimeCompare([]byte(username), []byte(a.username)) == 1
+	passOk := bcrypt.CompareHashAndPassword(a.passwordHash, []byte(password)) == nil
+
+	if !userOk || !passOk {
+		log.Printf("Failed login attempt from %s", client)
+		a.throttle.Failed(client, time.Now())
+		return ErrInvalidLogin
+	}
+
+	a.throttle.Succeeded(client)
+
+	return nil
+}
+
+func (a *Auth) sessionUser(c *gin.Context) (user string, csrfToken string, ok bool) {
+	value, err := c.Cookie(sessionCookie)
+
+	if err != nil || value == "" {
+		return
+	}
+
+	parts := strings.SplitN(value, ".", 2)
+	if len(parts) != 2 {
+		return
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return
+	}
+
+	payload := string(raw)
+	if !hmac.Equal([]byte(parts[1]), []byte(a.sign(payload))) {
+		return
+	}
+
+	fields := strings.Split(payload, "|")
+	if len(fields) != 3 {
+		return
+	}
+
+	expires, err := strconv.ParseInt(fields[2], 10, 64)
+	if err != nil || time.Now().Unix() > expires {
+		return
+	}
+
+	return fields[1], a.sign("csrf|" + fields[0]), true
+}
+
+func (a *Auth) proxyUser(c *gin.Context) (user string, csrfToken string, ok bool) {
+	if a.proxyHeader == "" {
+		return
+	}
+
+	user = c.GetHeader(a.proxyHeader)
+	if user == "" {
+		return "", "", false
+	}
+
+	host, _, err := net.SplitHostPort(c.Request.RemoteAddr)
+	if err != nil {
+		return "", "", false
+	}
+
+	ip := net.ParseIP(host)
+	for _, network := range a.trustedProxies {
+		if ip != nil && network.Contains(ip) {
+			return user, a.sign("csrf|proxy|" + user), true
+		}
+	}
+
+	log.Printf("ignoring %s header from untrusted address %s", a.proxyHeader, host)
+
+	return "", "", false
+}
+
+func (a *Auth) reject(c *gin.Context) {
+	if strings.HasPrefix(c.Request.URL.Path, "/api/") {
+		if a.passwordHash != nil {
+			c.Header("WWW-Authenticate", `Basic realm="ttlock2mqtt"`)
+		}
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "authentication required"})
+		return
+	}
+
+	if a.passwordHash == nil {
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+
+	c.Redirect(http.StatusFound, "/login?next="+url.QueryEscape(c.Request.URL.RequestURI()))
+	c.Abort()
+}
+
+func (a *Auth) setCookie(c *gin.Context, value string, maxAge int) {
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(sessionCookie, value, maxAge, "/", "", a.secure(c), true)
+}
+
+func (a *Auth) secure(c *gin.Context) bool {
+	return c.Request.TLS != nil || c.GetHeader("X-Forwarded-Proto") == "https"
+}
+
+// sign is keyed with the password hash as well so changing the password ends all sessions
+func (a *Auth) sign(payload string) string {
+	mac := hmac.New(sha256.New, append(append([]byte{}, a.secret...), a.passwordHash...))
+	mac.Write([]byte(payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// isScriptRequest reports whether the request could not have been sent by a cross-site form,
+// custom headers and JSON bodies require a CORS preflight that is never granted
+func isScriptRequest(c *gin.Context) bool {
+	return c.GetHeader("X-Requested-With") != "" || c.GetHeader(CSRFHeader) != "" || c.ContentType() == "application/json"
+}
+
+func isSafeMethod(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions
+}
@@ -0,0 +1,73 @@
+package auth
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	maxFailedLogins   = 5
+	failedLoginWindow = 5 * time.Minute
+)
+
+type failedLogins struct {
+	count int
+	since time.Time
+}
+
+// loginThrottle blocks clients after too many failed password checks, before bcrypt runs again
+type loginThrottle struct {
+	mu       sync.Mutex
+	failures map[string]*failedLogins
+}
+
+func newLoginThrottle() *loginThrottle {
+	return &loginThrottle{failures: map[string]*failedLogins{}}
+}
+
+// Allowed reports whether client may try a password now
+func (t *loginThrottle) Allowed(client string, now time.Time) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	f, ok := t.failures[client]
+
+	if !ok {
+		return true
+	}
+
+	if now.Sub(f.since) >= failedLoginWindow {
+		delete(t.failures, client)
+		return true
+	}
+
+	return f.count < maxFailedLogins
+}
+
+func (t *loginThrottle) Failed(client string, now time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	// Forget stale clients so the map does not grow unbounded
+	for c, f := range t.failures {
+		if now.Sub(f.since) >= failedLoginWindow {
+			delete(t.failures, c)
+		}
+	}
+
+	f, ok := t.failures[client]
+
+	if !ok {
+		f = &failedLogins{since: now}
+		t.failures[client] = f
+	}
+
+	f.count++
+}
+
+func (t *loginThrottle) Succeeded(client string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	delete(t.failures, client)
+}
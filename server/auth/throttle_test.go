@@ -0,0 +1,46 @@
+package auth
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLoginThrottle(t *testing.T) {
+	start := time.Now()
+
+	tests := []struct {
+		name     string
+		failures int
+		success  bool
+		at       time.Duration
+		want     bool
+	}{
+		{"no failures", 0, false, 0, true},
+		{"below limit", maxFailedLogins - 1, false, 0, true},
+		{"at limit", maxFailedLogins, false, 0, false},
+		{"window over", maxFailedLogins, false, failedLoginWindow, true},
+		{"success resets", maxFailedLogins - 1, true, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			th := newLoginThrottle()
+
+			for i := 0; i < tt.failures; i++ {
+				th.Failed("10.0.0.1", start)
+			}
+			if tt.success {
+				th.Succeeded("10.0.0.1")
+				th.Failed("10.0.0.1", start)
+			}
+
+			if got := th.Allowed("10.0.0.1", start.Add(tt.at)); got != tt.want {
+				t.Errorf("Allowed() = %v, want %v", got, tt.want)
+			}
+
+			if !th.Allowed("10.0.0.2", start) {
+				t.Errorf("other client is throttled")
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package handlers
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type apiLoginRequest struct {
+	Username string `json:"username" binding:"required"`
+	Password string `json:"password" binding:"required"`
+}
+
+func (h *Handlers) registerAPICredentials(g *gin.RouterGroup) {
+	g.GET("/credentials", h.apiGetCredentials())
+	g.POST("/credentials", h.apiPostCredentials())
+}
+
+func (h *Handlers) apiGetCredentials() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		r := h.Res(c)
+
+		creds, err := r.GetCredentials()
+
+		if err != nil {
+			log.Printf("Loading credentials failed: %s", err)
+			h.apiError(c, http.StatusInternalServerError, err)
+			return
+		}
+
+		resp := []apiCredentials{}
+		for _, cred := range creds {
+			resp = append(resp, toAPICredentials(cred))
+		}
+
+		c.JSON(http.StatusOK, resp)
+	}
+}
+
+func (h *Handlers) apiPostCredentials() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		r := h.Res(c)
+
+		var req apiLoginRequest
+
+		if err := c.ShouldBindJSON(&req); err != nil {
+			h.apiError(c, http.StatusBadRequest, err)
+			return
+		}
+
+		creds, err := r.GetCredentials()
+
+		if err != nil {
+			log.Printf("Loading credentials failed: %s", err)
+			h.apiError(c, http.StatusInternalServerError, err)
+			return
+		}
+
+		cred, err := h.ttlockService.Login(req.Username, hashPassword(req.Password))
+
+		if err != nil {
+			h.apiError(c, http.StatusUnauthorized, fmt.Errorf("login failed: %w", err))
+			return
+		}
+
+		err = h.credStorage.Save(creds.Add(cred))
+
+		if err != nil {
+			log.Printf("Saving credentials failed: %s", err)
+			h.apiError(c, http.StatusInternalServerError, err)
+			return
+		}
+
+		h.notifyChange()
+
+		c.JSON(http.StatusCreated, toAPICredentials(cred))
+	}
+}
@@ -0,0 +1,216 @@
+package handlers
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nikolai5slo/ttlock2mqtt/locks"
+	"github.com/nikolai5slo/ttlock2mqtt/ttlock"
+)
+
+type apiAddLocksRequest struct {
+	CredentialsID int32   `json:"credentials_id" binding:"required"`
+	LockIDs       []int32 `json:"lock_ids" binding:"required"`
+}
+
+func (h *Handlers) registerAPILocks(g *gin.RouterGroup) {
+	g.GET("/locks", h.apiGetLocks())
+	g.POST("/locks", h.apiPostLocks())
+	g.GET("/locks/:id", h.apiGetLock())
+	g.GET("/locks/:id/state", h.apiGetLockState())
+	g.POST("/locks/:id/lock", h.apiPostLockCommand(ttlock.Locked))
+	g.POST("/locks/:id/unlock", h.apiPostLockCommand(ttlock.Unlocked))
+}
+
+func (h *Handlers) apiGetLocks() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		r := h.Res(c)
+
+		managedLocks, err := r.GetManagedLocks()
+
+		if err != nil {
+			log.Printf("Loading locks failed: %s", err)
+			h.apiError(c, http.StatusInternalServerError, err)
+			return
+		}
+
+		resp := []apiLock{}
+		for _, l := range managedLocks {
+			resp = append(resp, toAPILock(l))
+		}
+
+		c.JSON(http.StatusOK, resp)
+	}
+}
+
+func (h *Handlers) apiPostLocks() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		r := h.Res(c)
+
+		var req apiAddLocksRequest
+
+		if err := c.ShouldBindJSON(&req); err != nil {
+			h.apiError(c, http.StatusBadRequest, err)
+			return
+		}
+
+		creds, err := r.GetCredentials()
+
+		if err != nil {
+			log.Printf("Loading credentials failed: %s", err)
+			h.apiError(c, http.StatusInternalServerError, err)
+			return
+		}
+
+		cred := creds.Get(req.CredentialsID)
+
+		if cred == nil {
+			h.apiNotFound(c, fmt.Errorf("cannot find credentials for the ID: %d", req.CredentialsID))
+			return
+		}
+
+		accountLocks, err := h.ttlockService.GetLocks(*cred, ttlock.LockFilter{})
+
+		if err != nil {
+			log.Printf("Getting locks from API failed: %s", err)
+			h.apiError(c, http.StatusBadGateway, err)
+			return
+		}
+
+		managedLocks, err := r.GetManagedLocks()
+
+		if err != nil {
+			log.Printf("Loading locks failed: %s", err)
+			h.apiError(c, http.StatusInternalServerError, err)
+			return
+		}
+
+		resp := []apiLock{}
+
+		for _, lockID := range req.LockIDs {
+			found := false
+
+			for _, lock := range accountLocks {
+				if lock.LockId == lockID {
+					ml := locks.ManagedLock{
+						Lock:          lock,
+						CredentialsID: cred.ID,
+					}
+					managedLocks = managedLocks.Add(ml)
+					resp = append(resp, toAPILock(ml))
+					found = true
+					break
+				}
+			}
+
+			if !found {
+				h.apiNotFound(c, fmt.Errorf("lock %d not found on the account", lockID))
+				return
+			}
+		}
+
+		err = h.lockStorage.Save(managedLocks)
+
+		if err != nil {
+			log.Printf("Saving locks failed: %s", err)
+			h.apiError(c, http.StatusInternalServerError, err)
+			return
+		}
+
+		h.notifyChange()
+
+		c.JSON(http.StatusCreated, resp)
+	}
+}
+
+func (h *Handlers) apiGetLock() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		r := h.Res(c)
+
+		l, err := r.GetManagedLock()
+
+		if err != nil {
+			h.apiNotFound(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, toAPILock(*l))
+	}
+}
+
+func (h *Handlers) apiGetLockState() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		r := h.Res(c)
+
+		l, err := r.GetManagedLock()
+
+		if err != nil {
+			h.apiNotFound(c, err)
+			return
+		}
+
+		cred, err := r.GetLockCredentials(l)
+
+		if err != nil {
+			h.apiError(c, http.StatusInternalServerError, err)
+			return
+		}
+
+		status, err := h.ttlockService.GetLockStatus(*cred, l.Lock)
+
+		if err != nil {
+			log.Printf("Getting lock status failed [%d]: %s", l.LockId, err)
+			h.apiError(c, http.StatusBadGateway, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, apiLockState{
+			LockID: l.LockId,
+			State:  lockStateName(status),
+		})
+	}
+}
+
+func (h *Handlers) apiPostLockCommand(ls ttlock.LockStatus) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		r := h.Res(c)
+
+		l, err := r.GetManagedLock()
+
+		if err != nil {
+			h.apiNotFound(c, err)
+			return
+		}
+
+		if h.lockCommander != nil {
+			err = h.lockCommander.Command(l.LockId, ls)
+		} else {
+			var cred *ttlock.Credentials
+			cred, err = r.GetLockCredentials(l)
+
+			if err != nil {
+				h.apiError(c, http.StatusInternalServerError, err)
+				return
+			}
+
+			if ls == ttlock.Locked {
+				err = h.ttlockService.Lock(*cred, l.Lock)
+			} else {
+				err = h.ttlockService.Unlock(*cred, l.Lock)
+			}
+		}
+
+		if err != nil {
+			log.Printf("Lock command failed [%d]: %s", l.LockId, err)
+			h.apiError(c, http.StatusBadGateway, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, apiLockState{
+			LockID: l.LockId,
+			State:  lockStateName(ls),
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package handlers
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handlers) registerAPIRemove(g *gin.RouterGroup) {
+	g.DELETE("/credentials/:id", h.apiDeleteCredentials())
+	g.DELETE("/locks/:id", h.apiDeleteLock())
+}
+
+func (h *Handlers) apiDeleteCredentials() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		r := h.Res(c)
+
+		credID, err := strconv.Atoi(c.Param("id"))
+
+		if err != nil {
+			h.apiError(c, http.StatusBadRequest, fmt.Errorf("invalid credentials ID"))
+			return
+		}
+
+		creds, err := r.GetCredentials()
+
+		if err != nil {
+			log.Printf("Loading credentials failed: %s", err)
+			h.apiError(c, http.StatusInternalServerError, err)
+			return
+		}
+
+		if creds.Find(int32(credID)) < 0 {
+			h.apiNotFound(c, fmt.Errorf("cannot find credentials for the ID: %d", credID))
+			return
+		}
+
+		managedLocks, err := r.GetManagedLocks()
+
+		if err != nil {
+			log.Printf("Loading locks failed: %s", err)
+			h.apiError(c, http.StatusInternalServerError, err)
+			return
+		}
+
+		// Locks would be left without a way to authenticate
+		for _, l := range managedLocks {
+			if l.CredentialsID == int32(credID) {
+				h.apiError(c, http.StatusConflict, fmt.Errorf("credentials are used by managed lock %d", l.LockId))
+				return
+			}
+		}
+
+		err = h.credStorage.Save(creds.Remove(int32(credID)))
+
+		if err != nil {
+			log.Printf("Saving credentials failed: %s", err)
+			h.apiError(c, http.StatusInternalServerError, err)
+			return
+		}
+
+		h.notifyChange()
+
+		c.Status(http.StatusNoContent)
+	}
+}
+
+func (h *Handlers) apiDeleteLock() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		r := h.Res(c)
+
+		lockID, err := strconv.Atoi(c.Param("id"))
+
+		if err != nil {
+			h.apiError(c, http.StatusBadRequest, fmt.Errorf("invalid lock ID"))
+			return
+		}
+
+		managedLocks, err := r.GetManagedLocks()
+
+		if err != nil {
+			log.Printf("Loading locks failed: %s", err)
+			h.apiError(c, http.StatusInternalServerError, err)
+			return
+		}
+
+		if managedLocks.Find(int32(lockID)) < 0 {
+			h.apiNotFound(c, fmt.Errorf("cannot find managed lock for the ID: %d", lockID))
+			return
+		}
+
+		err = h.lockStorage.Save(managedLocks.Remove(int32(lockID)))
+
+		if err != nil {
+			log.Printf("Saving locks failed: %s", err)
+			h.apiError(c, http.StatusInternalServerError, err)
+			return
+		}
+
+		h.notifyChange()
+
+		c.Status(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,101 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nikolai5slo/ttlock2mqtt/credentials"
+	"github.com/nikolai5slo/ttlock2mqtt/locks"
+	"github.com/nikolai5slo/ttlock2mqtt/ttlock"
+)
+
+// LockCommander executes lock commands on managed locks
+type LockCommander interface {
+	Command(lockID int32, ls ttlock.LockStatus) error
+}
+
+type apiErrorResponse struct {
+	Error string `json:"error"`
+}
+
+type apiCredentials struct {
+	ID         int32     `json:"id"`
+	Username   string    `json:"username"`
+	ExpiresAt  time.Time `json:"expires_at"`
+	NeedsLogin bool      `json:"needs_login"`
+}
+
+type apiLock struct {
+	LockID           int32   `json:"lock_id"`
+	LockAlias        string  `json:"lock_alias"`
+	LockName         string  `json:"lock_name"`
+	LockMac          *string `json:"lock_mac,omitempty"`
+	ElectricQuantity *int32  `json:"electric_quantity,omitempty"`
+	HasGateway       bool    `json:"has_gateway"`
+	GroupID          *int32  `json:"group_id,omitempty"`
+	GroupName        *string `json:"group_name,omitempty"`
+	CredentialsID    int32   `json:"credentials_id"`
+	Missing          bool    `json:"missing"`
+}
+
+type apiLockState struct {
+	LockID int32  `json:"lock_id"`
+	State  string `json:"state"`
+}
+
+func (h *Handlers) registerAPI(e gin.IRouter) {
+	api := e.Group("/api/v1")
+
+	h.registerAPICredentials(api)
+	h.registerAPILocks(api)
+	h.registerAPIRemove(api)
+}
+
+func (h *Handlers) apiError(c *gin.Context, status int, err error) {
+	c.JSON(status, apiErrorResponse{Error: err.Error()})
+}
+
+func toAPICredentials(cred credentials.Credentials) apiCredentials {
+	return apiCredentials{
+		ID:         cred.ID,
+		Username:   cred.Username,
+		ExpiresAt:  cred.ExpiresAt,
+		NeedsLogin: cred.NeedsLogin,
+	}
+}
+
+func toAPILock(l locks.ManagedLock) apiLock {
+	return apiLock{
+		LockID:           l.LockId,
+		LockAlias:        l.LockAlias,
+		LockName:         l.LockName,
+		LockMac:          l.LockMac,
+		ElectricQuantity: l.ElectricQuantity,
+		HasGateway:       l.HasGateway != nil && *l.HasGateway == 1,
+		GroupID:          l.GroupId,
+		GroupName:        l.GroupName,
+		CredentialsID:    l.CredentialsID,
+		Missing:          l.Missing,
+	}
+}
+
+func lockStateName(ls ttlock.LockStatus) string {
+	switch ls {
+	case ttlock.Locked:
+		return "locked"
+	case ttlock.Unlocked:
+		return "unlocked"
+	case ttlock.Locking:
+		return "locking"
+	case ttlock.Unlocking:
+		return "unlocking"
+	case ttlock.Jammed:
+		return "jammed"
+	}
+	return "unknown"
+}
+
+func (h *Handlers) apiNotFound(c *gin.Context, err error) {
+	h.apiError(c, http.StatusNotFound, err)
+}
@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nikolai5slo/ttlock2mqtt/ttlock"
+)
+
+// RecordsReceiver is notified about records pushed by the TTLock cloud
+type RecordsReceiver interface {
+	HandleRecords(lockID int32, records []ttlock.Record) error
+}
+
+func (h *Handlers) registerCallback(e *gin.Engine) {
+	if h.recordsReceiver == nil {
+		return
+	}
+
+	// The secret in the callback URL is the only thing identifying the TTLock cloud
+	e.POST("/callback/:secret", h.postCallback())
+}
+
+func (h *Handlers) postCallback() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		r := h.Res(c)
+
+		if subtle.ConstantTimeCompare([]byte(c.Param("secret")), []byte(h.callbackSecret)) != 1 {
+			c.String(http.StatusNotFound, "not found")
+			return
+		}
+
+		lockID, err := strconv.Atoi(c.PostForm("lockId"))
+
+		if err != nil {
+			c.String(http.StatusBadRequest, "invalid lockId")
+			return
+		}
+
+		var records []ttlock.Record
+
+		if err := json.Unmarshal([]byte(c.PostForm("records")), &records); err != nil {
+			log.Printf("Parsing callback records failed: %s", err)
+			c.String(http.StatusBadRequest, "invalid records")
+			return
+		}
+
+		managedLocks, err := r.GetManagedLocks()
+
+		if err != nil {
+			log.Printf("Loading locks failed: %s", err)
+			c.String(http.StatusInternalServerError, "internal error")
+			return
+		}
+
+		// Callbacks arrive for every lock of the account, only managed ones are relevant
+		if managedLocks.Find(int32(lockID)) < 0 {
+			c.String(http.StatusOK, "success")
+			return
+		}
+
+		if err := h.recordsReceiver.HandleRecords(int32(lockID), records); err != nil {
+			log.Printf("Handling callback for lock %d failed: %s", lockID, err)
+		}
+
+		// TTLock expects "success" or it will keep retrying the push
+		c.String(http.StatusOK, "success")
+	}
+}
@@ -3,6 +3,8 @@ package handlers
 import (
 	"log"
 	"net/http"
+	"net/url"
+	"sort"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
@@ -10,14 +12,22 @@ import (
 	"github.com/nikolai5slo/ttlock2mqtt/ttlock"
 )
 
+const locksModalPageSize = 20
+
+type lockGroup struct {
+	ID   int32
+	Name string
+}
+
 func (h *Handlers) getLocksForCredentials() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		var errors []string
 
 		sid, _ := c.Params.Get("id")
-		lockID, err := strconv.Atoi(sid)
+		credID, err := strconv.Atoi(sid)
 		if err != nil {
-			c.Redirect(301, "/credentials")
+			c.Redirect(http.StatusFound, "/credentials")
+			return
 		}
 
 		// Get all credentials
@@ -33,13 +43,22 @@ func (h *Handlers) getLocksForCredentials() gin.HandlerFunc {
 		}
 
 		// Find credentials
-		cred := creds.Get(int32(lockID))
+		cred := creds.Get(int32(credID))
 
-		if c == nil {
-			c.Redirect(301, "/credentials")
+		if cred == nil {
+			c.Redirect(http.StatusFound, "/credentials")
+			return
 		}
 
-		locks, err := h.ttlockService.GetLocks(*cred)
+		search := c.Query("q")
+		filter := ttlock.LockFilter{Alias: search}
+
+		if groupID, err := strconv.Atoi(c.Query("group")); err == nil {
+			gid := int32(groupID)
+			filter.GroupID = &gid
+		}
+
+		locks, err := h.ttlockService.GetLocks(*cred, filter)
 
 		if err != nil {
 			log.Printf("Getting locks from API failed: %s", err)
@@ -49,16 +68,108 @@ func (h *Handlers) getLocksForCredentials() gin.HandlerFunc {
 			return
 		}
 
-		h.rednerCredentialsWithLocksModal(c, creds, cred, locks, errors)
+		groups := lockGroups(locks)
+
+		// The group options need the locks of all groups
+		if filter.GroupID != nil {
+			allLocks, err := h.ttlockService.GetLocks(*cred, ttlock.LockFilter{Alias: search})
+
+			if err != nil {
+				log.Printf("Getting locks from API failed: %s", err)
+			} else {
+				groups = lockGroups(allLocks)
+			}
+		}
+
+		page, err := strconv.Atoi(c.Query("page"))
+		if err != nil {
+			page = 1
+		}
+
+		page, pages, start, end := paginate(len(locks), page, locksModalPageSize)
+		pageLocks := locks[start:end]
+
+		h.renderHTML(c, http.StatusOK, "credentials.html", gin.H{
+			"credentials": creds,
+			"errors":      errors,
+			"modal":       true,
+			"locks":       pageLocks,
+			"credID":      cred.ID,
+			"search":      search,
+			"groups":      groups,
+			"group":       c.Query("group"),
+			"page":        page,
+			"pages":       pages,
+			"total":       len(locks),
+			"prevPage":    locksModalPageURL(cred.ID, search, c.Query("group"), page-1),
+			"nextPage":    locksModalPageURL(cred.ID, search, c.Query("group"), page+1),
+		})
 	}
 }
 
-func (h *Handlers) rednerCredentialsWithLocksModal(c *gin.Context, creds credentials.CredentialsList, cred *credentials.Credentials, locks []ttlock.Lock, errors []string) {
-	c.HTML(http.StatusOK, "credentials.html", gin.H{
-		"credentials": creds,
-		"errors":      errors,
-		"modal":       true,
-		"locks":       locks,
-		"credID":      cred.ID,
+func lockGroups(locks []ttlock.Lock) []lockGroup {
+	seen := map[int32]bool{}
+	var groups []lockGroup
+
+	for _, l := range locks {
+		if l.GroupId == nil || seen[*l.GroupId] {
+			continue
+		}
+
+		seen[*l.GroupId] = true
+
+		g := lockGroup{ID: *l.GroupId, Name: strconv.Itoa(int(*l.GroupId))}
+		if l.GroupName != nil && *l.GroupName != "" {
+			g.Name = *l.GroupName
+		}
+
+		groups = append(groups, g)
+	}
+
+	sort.Slice(groups, func(i, j int) bool {
+		return groups[i].Name < groups[j].Name
 	})
+
+	return groups
+}
+
+func locksModalPageURL(credID int32, search string, group string, page int) string {
+	q := url.Values{}
+
+	if search != "" {
+		q.Set("q", search)
+	}
+
+	if group != "" {
+		q.Set("group", group)
+	}
+
+	q.Set("page", strconv.Itoa(page))
+
+	return "/credentials/" + strconv.Itoa(int(credID)) + "/locks?" + q.Encode()
+}
+
+// paginate clamps page to the available pages, an empty list still has a first page.
+// It returns the page, the number of pages and the item range of the page.
+func paginate(total int, page int, size int) (int, int, int, int) {
+	pages := (total + size - 1) / size
+
+	if page > pages {
+		page = pages
+	}
+	if page < 1 {
+		page = 1
+	}
+
+	start := (page - 1) * size
+	end := start + size
+
+	if start > total {
+		start = total
+	}
+	if end > total {
+		end = total
+	}
+
+	return page, pages, start, end
 }
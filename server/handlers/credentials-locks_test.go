@@ -0,0 +1,36 @@
+package handlers
+
+import "testing"
+
+func TestPaginate(t *testing.T) {
+	tests := []struct {
+		name      string
+		total     int
+		page      int
+		wantPage  int
+		wantPages int
+		wantStart int
+		wantEnd   int
+	}{
+		{"no locks", 0, 1, 1, 0, 0, 0},
+		{"no locks, page requested", 0, 3, 1, 0, 0, 0},
+		{"single page", 5, 1, 1, 1, 0, 5},
+		{"full page", 20, 1, 1, 1, 0, 20},
+		{"second page", 45, 2, 2, 3, 20, 40},
+		{"last partial page", 45, 3, 3, 3, 40, 45},
+		{"past last page", 45, 9, 3, 3, 40, 45},
+		{"zero page", 45, 0, 1, 3, 0, 20},
+		{"negative page", 45, -1, 1, 3, 0, 20},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, pages, start, end := paginate(tt.total, tt.page, 20)
+
+			if page != tt.wantPage || pages != tt.wantPages || start != tt.wantStart || end != tt.wantEnd {
+				t.Errorf("paginate() = %d, %d, %d, %d, want %d, %d, %d, %d",
+					page, pages, start, end, tt.wantPage, tt.wantPages, tt.wantStart, tt.wantEnd)
+			}
+		})
+	}
+}
@@ -6,15 +6,17 @@ import (
 	"fmt"
 	"log"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/nikolai5slo/ttlock2mqtt/credentials"
 )
 
-func (h *Handlers) registerCredentials(e *gin.Engine) {
+func (h *Handlers) registerCredentials(e gin.IRouter) {
 	e.POST("/credentials", h.postCredentials())
 	e.GET("/credentials", h.getCredentials())
 	e.GET("/credentials/:id/locks", h.getLocksForCredentials())
+	e.POST("/credentials/:id/delete", h.deleteCredentials())
 }
 
 func (h *Handlers) getCredentials() gin.HandlerFunc {
@@ -52,11 +54,7 @@ func (h *Handlers) postCredentials() gin.HandlerFunc {
 		username := c.PostForm("username")
 		password := c.PostForm("password")
 
-		// Convert password to md5
-		hash := md5.Sum([]byte(password))
-		password = hex.EncodeToString(hash[:])
-
-		cred, err := h.ttlockService.Login(username, password)
+		cred, err := h.ttlockService.Login(username, hashPassword(password))
 		if err != nil {
 			errors = append(errors, fmt.Sprintf("Login failed: %s", err))
 			h.rednerCredentials(c, creds, errors)
@@ -74,14 +72,80 @@ func (h *Handlers) postCredentials() gin.HandlerFunc {
 			return
 		}
 
+		h.notifyChange()
+
 		h.rednerCredentials(c, newCreds, errors)
 	}
 }
 
+func (h *Handlers) deleteCredentials() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		r := h.Res(c)
+
+		errors := []string{}
+
+		creds, err := r.GetCredentials()
+
+		if err != nil {
+			log.Printf("Loading credentials failed: %s", err)
+			h.renderInternalError(c, err)
+			return
+		}
+
+		credID, err := strconv.Atoi(c.Param("id"))
+
+		if err != nil || creds.Find(int32(credID)) < 0 {
+			c.Redirect(http.StatusFound, "/credentials")
+			return
+		}
+
+		managedLocks, err := r.GetManagedLocks()
+
+		if err != nil {
+			h.renderInternalError(c, err)
+			return
+		}
+
+		// Locks would be left without a way to authenticate
+		for _, l := range managedLocks {
+			if l.CredentialsID == int32(credID) {
+				errors = append(errors, fmt.Sprintf("Credentials are used by lock %s, remove it first.", l.LockAlias))
+			}
+		}
+
+		if len(errors) > 0 {
+			h.rednerCredentials(c, creds, errors)
+			return
+		}
+
+		newCreds := creds.Remove(int32(credID))
+
+		err = h.credStorage.Save(newCreds)
+
+		if err != nil {
+			log.Printf("Saving credentials failed: %s", err)
+			errors = append(errors, "Saving failed.")
+
+			h.rednerCredentials(c, creds, errors)
+			return
+		}
+
+		h.notifyChange()
+
+		c.Redirect(http.StatusSeeOther, "/credentials")
+	}
+}
+
 func (h *Handlers) rednerCredentials(c *gin.Context, creds credentials.CredentialsList, errors []string) {
-	c.HTML(http.StatusOK, "credentials.html", gin.H{
+	h.renderHTML(c, http.StatusOK, "credentials.html", gin.H{
 		"credentials": creds,
 		"errors":      errors,
 		"modal":       false,
 	})
 }
+
+// hashPassword converts password to md5 as expected by the TTLock API
+func hashPassword(password string) string {
+	hash := md5.Sum([]byte(password))
+	return hex.EncodeToString(hash[:])
+}
@@ -7,7 +7,7 @@ import (
 )
 
 func (h *Handlers) renderInternalError(c *gin.Context, err error) {
-	c.HTML(http.StatusInternalServerError, "error.html", gin.H{
+	h.renderHTML(c, http.StatusInternalServerError, "error.html", gin.H{
 		"message": err.Error(),
 	})
 }
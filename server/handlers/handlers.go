@@ -1,17 +1,31 @@
 package handlers
 
 import (
+	"fmt"
+	"log"
+
 	"github.com/gin-gonic/gin"
 	"github.com/schollz/jsonstore"
 	"github.com/nikolai5slo/ttlock2mqtt/credentials"
 	"github.com/nikolai5slo/ttlock2mqtt/locks"
+	"github.com/nikolai5slo/ttlock2mqtt/server/auth"
 	"github.com/nikolai5slo/ttlock2mqtt/ttlock"
 )
 
 type Handlers struct {
-	credStorage   credentials.Storage
-	lockStorage   locks.Storage
-	ttlockService ttlock.Service
+	credStorage     credentials.Storage
+	lockStorage     locks.Storage
+	ttlockService   ttlock.Service
+	recordsReceiver RecordsReceiver
+	callbackSecret  string
+	lockCommander   LockCommander
+	changeListener  ChangeListener
+	auth            *auth.Auth
+}
+
+// ChangeListener is notified after locks or credentials were modified
+type ChangeListener interface {
+	StorageChanged()
 }
 
 type Conf func(*Handlers) error
@@ -37,6 +51,40 @@ func WithLockStorage(lockStorage locks.Storage) Conf {
 	}
 }
 
+// WithRecordsReceiver enables TTLock cloud callbacks on /callback/<secret>
+func WithRecordsReceiver(receiver RecordsReceiver, secret string) Conf {
+	return func(h *Handlers) error {
+		if secret == "" {
+			return fmt.Errorf("callback secret is required")
+		}
+
+		h.recordsReceiver = receiver
+		h.callbackSecret = secret
+		return nil
+	}
+}
+
+func WithLockCommander(commander LockCommander) Conf {
+	return func(h *Handlers) error {
+		h.lockCommander = commander
+		return nil
+	}
+}
+
+func WithChangeListener(listener ChangeListener) Conf {
+	return func(h *Handlers) error {
+		h.changeListener = listener
+		return nil
+	}
+}
+
+func WithAuth(a *auth.Auth) Conf {
+	return func(h *Handlers) error {
+		h.auth = a
+		return nil
+	}
+}
+
 func WithStoreFile(filePath string) Conf {
 	return func(h *Handlers) error {
 		// Credentials store
@@ -73,7 +121,29 @@ func New(cfg ...Conf) (*Handlers, error) {
 }
 
 func (h *Handlers) Register(e *gin.Engine) {
-	h.registerIndex(e)
-	h.registerCredentials(e)
-	h.registerLocks(e)
+	// TTLock cloud cannot authenticate
+	h.registerCallback(e)
+
+	var r gin.IRouter = e
+
+	if h.auth != nil && h.auth.Enabled() {
+		h.registerLogin(e)
+
+		r = e.Group("/", h.auth.Middleware())
+
+		h.registerLogout(r)
+	} else {
+		log.Printf("authentication is not configured, web UI is open to everyone")
+	}
+
+	h.registerIndex(r)
+	h.registerCredentials(r)
+	h.registerLocks(r)
+	h.registerAPI(r)
+}
+
+func (h *Handlers) notifyChange() {
+	if h.changeListener != nil {
+		h.changeListener.StorageChanged()
+	}
 }
@@ -4,7 +4,7 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-func (h *Handlers) registerIndex(e *gin.Engine) {
+func (h *Handlers) registerIndex(e gin.IRouter) {
 	e.GET("/", h.getIndex())
 }
 
@@ -0,0 +1,206 @@
+package handlers
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nikolai5slo/ttlock2mqtt/locks"
+	"github.com/nikolai5slo/ttlock2mqtt/ttlock"
+)
+
+func (h *Handlers) registerPasscodes(e gin.IRouter) {
+	e.GET("/locks/:id/passcodes", h.getPasscodes())
+	e.POST("/locks/:id/passcodes", h.postPasscodes())
+	e.POST("/locks/:id/passcodes/:passcodeID", h.postPasscode())
+	e.POST("/locks/:id/passcodes/:passcodeID/delete", h.deletePasscode())
+}
+
+func (h *Handlers) getPasscodes() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		r := h.Res(c)
+
+		l, err := r.GetManagedLock()
+
+		if err != nil {
+			c.Redirect(http.StatusFound, "/locks")
+			return
+		}
+
+		h.renderPasscodes(c, l, []string{})
+	}
+}
+
+func (h *Handlers) postPasscodes() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		r := h.Res(c)
+
+		errors := []string{}
+
+		l, err := r.GetManagedLock()
+
+		if err != nil {
+			c.Redirect(http.StatusFound, "/locks")
+			return
+		}
+
+		cred, err := r.GetLockCredentials(l)
+
+		if err != nil {
+			h.renderInternalError(c, err)
+			return
+		}
+
+		p, err := r.GetPostedPasscode()
+
+		if err != nil {
+			errors = append(errors, err.Error())
+			h.renderPasscodes(c, l, errors)
+			return
+		}
+
+		p, err = h.ttlockService.AddPasscode(*cred, l.Lock, p)
+
+		if err != nil {
+			log.Printf("Adding passcode failed [%d]: %s", l.LockId, err)
+			errors = append(errors, fmt.Sprintf("Adding passcode failed: %s", err))
+		} else {
+			log.Printf("Added passcode %d to lock %d", p.ID, l.LockId)
+		}
+
+		h.renderPasscodes(c, l, errors)
+	}
+}
+
+func (h *Handlers) postPasscode() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		r := h.Res(c)
+
+		errors := []string{}
+
+		l, err := r.GetManagedLock()
+
+		if err != nil {
+			c.Redirect(http.StatusFound, "/locks")
+			return
+		}
+
+		cred, err := r.GetLockCredentials(l)
+
+		if err != nil {
+			h.renderInternalError(c, err)
+			return
+		}
+
+		passcodeID, err := strconv.Atoi(c.Param("passcodeID"))
+
+		if err != nil {
+			c.Redirect(http.StatusFound, fmt.Sprintf("/locks/%d/passcodes", l.LockId))
+			return
+		}
+
+		current, err := h.getPasscode(*cred, l, int32(passcodeID))
+
+		if err != nil {
+			log.Printf("Getting passcode failed [%d]: %s", l.LockId, err)
+			errors = append(errors, fmt.Sprintf("Changing passcode failed: %s", err))
+			h.renderPasscodes(c, l, errors)
+			return
+		}
+
+		p, err := r.GetPostedPasscodeChange(current)
+
+		if err != nil {
+			errors = append(errors, err.Error())
+			h.renderPasscodes(c, l, errors)
+			return
+		}
+
+		err = h.ttlockService.ChangePasscode(*cred, l.Lock, p)
+
+		if err != nil {
+			log.Printf("Changing passcode failed [%d]: %s", l.LockId, err)
+			errors = append(errors, fmt.Sprintf("Changing passcode failed: %s", err))
+		}
+
+		h.renderPasscodes(c, l, errors)
+	}
+}
+
+func (h *Handlers) deletePasscode() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		r := h.Res(c)
+
+		errors := []string{}
+
+		l, err := r.GetManagedLock()
+
+		if err != nil {
+			c.Redirect(http.StatusFound, "/locks")
+			return
+		}
+
+		cred, err := r.GetLockCredentials(l)
+
+		if err != nil {
+			h.renderInternalError(c, err)
+			return
+		}
+
+		passcodeID, err := strconv.Atoi(c.Param("passcodeID"))
+
+		if err != nil {
+			c.Redirect(http.StatusFound, fmt.Sprintf("/locks/%d/passcodes", l.LockId))
+			return
+		}
+
+		err = h.ttlockService.DeletePasscode(*cred, l.Lock, int32(passcodeID))
+
+		if err != nil {
+			log.Printf("Deleting passcode failed [%d]: %s", l.LockId, err)
+			errors = append(errors, fmt.Sprintf("Deleting passcode failed: %s", err))
+		}
+
+		h.renderPasscodes(c, l, errors)
+	}
+}
+
+// getPasscode looks up the passcode on the lock, so the values not posted can be kept
+func (h *Handlers) getPasscode(cred ttlock.Credentials, l *locks.ManagedLock, passcodeID int32) (ttlock.Passcode, error) {
+	passcodes, err := h.ttlockService.GetPasscodes(cred, l.Lock)
+
+	if err != nil {
+		return ttlock.Passcode{}, err
+	}
+
+	for _, p := range passcodes {
+		if p.ID == passcodeID {
+			return p, nil
+		}
+	}
+
+	return ttlock.Passcode{}, fmt.Errorf("cannot find passcode %d", passcodeID)
+}
+
+func (h *Handlers) renderPasscodes(c *gin.Context, l *locks.ManagedLock, errors []string) {
+	var passcodes []ttlock.Passcode
+
+	cred, err := h.Res(c).GetLockCredentials(l)
+
+	if err == nil {
+		passcodes, err = h.ttlockService.GetPasscodes(*cred, l.Lock)
+	}
+
+	if err != nil {
+		log.Printf("Getting passcodes failed [%d]: %s", l.LockId, err)
+		errors = append(errors, "Failed to load passcodes. Check server logs.")
+	}
+
+	h.renderHTML(c, http.StatusOK, "passcodes.html", gin.H{
+		"lock":      l,
+		"passcodes": passcodes,
+		"errors":    errors,
+	})
+}
@@ -1,15 +1,19 @@
 package handlers
 
 import (
+	"log"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
 	"github.com/nikolai5slo/ttlock2mqtt/locks"
 )
 
-func (h *Handlers) registerLocks(e *gin.Engine) {
+func (h *Handlers) registerLocks(e gin.IRouter) {
 	e.GET("/locks", h.getLocks())
 	e.POST("/locks", h.postLocks())
+	e.POST("/locks/:id/delete", h.deleteLock())
+
+	h.registerPasscodes(e)
 }
 
 func (h *Handlers) getLocks() gin.HandlerFunc {
@@ -67,14 +71,53 @@ func (h *Handlers) postLocks() gin.HandlerFunc {
 
 		if err != nil {
 			errors = append(errors, "Failed to save locks")
+		} else {
+			h.notifyChange()
 		}
 
 		h.renderLocks(c, managedLocks, errors)
 	}
 }
 
+func (h *Handlers) deleteLock() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		r := h.Res(c)
+
+		errors := []string{}
+
+		managedLocks, err := r.GetManagedLocks()
+
+		if err != nil {
+			h.renderInternalError(c, err)
+			return
+		}
+
+		l, err := r.GetManagedLock()
+
+		if err != nil {
+			c.Redirect(http.StatusFound, "/locks")
+			return
+		}
+
+		newLocks := managedLocks.Remove(l.LockId)
+
+		err = h.lockStorage.Save(newLocks)
+
+		if err != nil {
+			log.Printf("Saving locks failed: %s", err)
+			errors = append(errors, "Failed to save locks")
+			h.renderLocks(c, managedLocks, errors)
+			return
+		}
+
+		h.notifyChange()
+
+		c.Redirect(http.StatusSeeOther, "/locks")
+	}
+}
+
 func (h *Handlers) renderLocks(c *gin.Context, l locks.LockList, errors []string) {
-	c.HTML(http.StatusOK, "locks.html", gin.H{
+	h.renderHTML(c, http.StatusOK, "locks.html", gin.H{
 		"locks":  l,
 		"errors": errors,
 	})
@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nikolai5slo/ttlock2mqtt/server/auth"
+)
+
+func (h *Handlers) registerLogin(e gin.IRouter) {
+	e.GET("/login", h.getLogin())
+	e.POST("/login", h.postLogin())
+}
+
+func (h *Handlers) registerLogout(e gin.IRouter) {
+	e.POST("/logout", h.postLogout())
+}
+
+func (h *Handlers) getLogin() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		h.renderLogin(c, c.Query("next"), []string{})
+	}
+}
+
+func (h *Handlers) postLogin() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		errors := []string{}
+
+		next := c.PostForm("next")
+
+		if !h.auth.CheckLoginCSRF(c) {
+			errors = append(errors, "The login form expired, please try again.")
+			h.renderLogin(c, next, errors)
+			return
+		}
+
+		err := h.auth.Login(c, c.PostForm("username"), c.PostForm("password"))
+
+		if err == auth.ErrTooManyAttempts {
+			errors = append(errors, "Too many failed logins, try again later.")
+			h.renderLogin(c, next, errors)
+			return
+		}
+
+		if err != nil {
+			errors = append(errors, "Invalid username or password.")
+			h.renderLogin(c, next, errors)
+			return
+		}
+
+		// Only redirect within the app
+		if !strings.HasPrefix(next, "/") || strings.HasPrefix(next, "//") {
+			next = "/"
+		}
+
+		c.Redirect(http.StatusFound, next)
+	}
+}
+
+func (h *Handlers) postLogout() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		h.auth.Logout(c)
+		c.Redirect(http.StatusFound, "/login")
+	}
+}
+
+func (h *Handlers) renderLogin(c *gin.Context, next string, errors []string) {
+	csrfToken, err := h.auth.LoginCSRF(c)
+
+	if err != nil {
+		h.renderInternalError(c, err)
+		return
+	}
+
+	// Login page has no session, its form token is used instead
+	c.Set(auth.ContextCSRF, csrfToken)
+
+	h.renderHTML(c, http.StatusOK, "login.html", gin.H{
+		"next":   next,
+		"errors": errors,
+	})
+}
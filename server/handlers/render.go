@@ -0,0 +1,14 @@
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/nikolai5slo/ttlock2mqtt/server/auth"
+)
+
+// renderHTML renders template with the session data used by the layout
+func (h *Handlers) renderHTML(c *gin.Context, status int, name string, data gin.H) {
+	data["csrf"] = c.GetString(auth.ContextCSRF)
+	data["user"] = c.GetString(auth.ContextUser)
+
+	c.HTML(status, name, data)
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"log"
 	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/nikolai5slo/ttlock2mqtt/credentials"
@@ -11,6 +12,9 @@ import (
 	"github.com/nikolai5slo/ttlock2mqtt/ttlock"
 )
 
+// Format used by the datetime-local form inputs
+const dateTimeLocal = "2006-01-02T15:04"
+
 type Resource struct {
 	h *Handlers
 	c *gin.Context
@@ -62,7 +66,7 @@ func (r *Resource) GetSelectedLocks() (mLocks []ttlock.Lock, err error) {
 		return
 	}
 
-	l, err := r.h.ttlockService.GetLocks(*cred)
+	l, err := r.h.ttlockService.GetLocks(*cred, ttlock.LockFilter{})
 
 	if err != nil {
 		return
@@ -96,3 +100,97 @@ func (r *Resource) GetSelectedLocks() (mLocks []ttlock.Lock, err error) {
 
 	return
 }
+
+func (r *Resource) GetManagedLock() (lock *locks.ManagedLock, err error) {
+	lockID, err := strconv.Atoi(r.c.Param("id"))
+
+	if err != nil {
+		return
+	}
+
+	managedLocks, err := r.GetManagedLocks()
+
+	if err != nil {
+		return
+	}
+
+	idx := managedLocks.Find(int32(lockID))
+	if idx < 0 {
+		return nil, fmt.Errorf("cannot find managed lock for the ID: %d", lockID)
+	}
+
+	return &managedLocks[idx], nil
+}
+
+func (r *Resource) GetLockCredentials(l *locks.ManagedLock) (cred *credentials.Credentials, err error) {
+	creds, err := r.GetCredentials()
+
+	if err != nil {
+		return
+	}
+
+	cred = creds.Get(l.CredentialsID)
+	if cred == nil {
+		return nil, fmt.Errorf("cannot find credentials for the ID: %d", l.CredentialsID)
+	}
+
+	return cred, nil
+}
+
+func (r *Resource) GetPostedPasscode() (ttlock.Passcode, error) {
+	return r.postedPasscode(ttlock.Passcode{Type: ttlock.PasscodePermanent})
+}
+
+// GetPostedPasscodeChange applies the posted form to the current passcode, keeping the type and
+// dates which are not posted. The code is only set if it was changed.
+func (r *Resource) GetPostedPasscodeChange(current ttlock.Passcode) (ttlock.Passcode, error) {
+	p, err := r.postedPasscode(current)
+
+	if p.Code == current.Code {
+		p.Code = ""
+	}
+
+	return p, err
+}
+
+func (r *Resource) postedPasscode(p ttlock.Passcode) (ttlock.Passcode, error) {
+	var err error
+
+	p.Name = r.c.PostForm("name")
+	p.Code = r.c.PostForm("code")
+
+	switch r.c.PostForm("type") {
+	case "one-time":
+		p.Type = ttlock.PasscodeOneTime
+	case "timed":
+		p.Type = ttlock.PasscodeTimed
+	case "permanent":
+		p.Type = ttlock.PasscodePermanent
+	}
+
+	if v := r.c.PostForm("start"); v != "" {
+		p.StartDate, err = time.ParseInLocation(dateTimeLocal, v, time.Local)
+
+		if err != nil {
+			return p, fmt.Errorf("invalid start date: %w", err)
+		}
+	}
+
+	if v := r.c.PostForm("end"); v != "" {
+		p.EndDate, err = time.ParseInLocation(dateTimeLocal, v, time.Local)
+
+		if err != nil {
+			return p, fmt.Errorf("invalid end date: %w", err)
+		}
+	}
+
+	if p.Type == ttlock.PasscodeTimed && p.EndDate.IsZero() {
+		return p, fmt.Errorf("timed passcode requires an end date")
+	}
+
+	if !p.EndDate.IsZero() && p.EndDate.Before(p.StartDate) {
+		return p, fmt.Errorf("end date is before start date")
+	}
+
+	return p, nil
+}
@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nikolai5slo/ttlock2mqtt/ttlock"
+)
+
+func postedForm(form url.Values) *Resource {
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest(http.MethodPost, "/", strings.NewReader(form.Encode()))
+	c.Request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	return (&Handlers{}).Res(c)
+}
+
+func TestGetPostedPasscodeChange(t *testing.T) {
+	start := time.Date(2024, 5, 1, 8, 0, 0, 0, time.Local)
+	end := time.Date(2024, 6, 1, 8, 0, 0, 0, time.Local)
+
+	current := ttlock.Passcode{
+		ID:        7,
+		Code:      "123456",
+		Name:      "Guest",
+		Type:      ttlock.PasscodeType(4),
+		StartDate: start,
+		EndDate:   end,
+	}
+
+	p, err := postedForm(url.Values{
+		"name": {"Cleaner"},
+		"code": {"123456"},
+		"type": {"other"},
+	}).GetPostedPasscodeChange(current)
+
+	if err != nil {
+		t.Fatalf("GetPostedPasscodeChange() error = %s", err)
+	}
+
+	if p.ID != 7 || p.Name != "Cleaner" {
+		t.Errorf("GetPostedPasscodeChange() = %+v, want ID 7 named Cleaner", p)
+	}
+	if p.Type != current.Type {
+		t.Errorf("type = %s, want the current type kept", p.Type)
+	}
+	if !p.StartDate.Equal(start) || !p.EndDate.Equal(end) {
+		t.Errorf("dates = %s - %s, want the current dates kept", p.StartDate, p.EndDate)
+	}
+	if p.Code != "" {
+		t.Errorf("code = %q, want empty for an unchanged code", p.Code)
+	}
+
+	p, err = postedForm(url.Values{
+		"name": {"Guest"},
+		"code": {"654321"},
+		"type": {"timed"},
+		"end":  {"2024-07-01T08:00"},
+	}).GetPostedPasscodeChange(current)
+
+	if err != nil {
+		t.Fatalf("GetPostedPasscodeChange() error = %s", err)
+	}
+
+	if p.Type != ttlock.PasscodeTimed || p.Code != "654321" {
+		t.Errorf("GetPostedPasscodeChange() = %+v, want a timed passcode with the new code", p)
+	}
+	if !p.StartDate.Equal(start) || !p.EndDate.Equal(time.Date(2024, 7, 1, 8, 0, 0, 0, time.Local)) {
+		t.Errorf("dates = %s - %s, want the start kept and the end changed", p.StartDate, p.EndDate)
+	}
+}
+
+func TestGetPostedPasscodeDefaultsToPermanent(t *testing.T) {
+	p, err := postedForm(url.Values{"name": {"Guest"}}).GetPostedPasscode()
+
+	if err != nil {
+		t.Fatalf("GetPostedPasscode() error = %s", err)
+	}
+
+	if p.Type != ttlock.PasscodePermanent {
+		t.Errorf("type = %s, want permanent", p.Type)
+	}
+}
@@ -0,0 +1,78 @@
+package sqlite
+
+import (
+	"database/sql"
+	"fmt"
+
+	_ "modernc.org/sqlite"
+)
+
+// Open opens the database, configured for concurrent access from the web handlers and the controller
+func Open(path string) (*sql.DB, error) {
+	db, err := sql.Open("sqlite", fmt.Sprintf("file:%s?_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)&_pragma=foreign_keys(1)", path))
+
+	if err != nil {
+		return nil, err
+	}
+
+	// SQLite allows a single writer, serialize in the pool instead of failing with SQLITE_BUSY
+	db.SetMaxOpenConns(1)
+
+	if err := db.Ping(); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	_, err = db.Exec(`CREATE TABLE IF NOT EXISTS schema_migrations (
+		component TEXT NOT NULL,
+		version INTEGER NOT NULL,
+		PRIMARY KEY (component, version)
+	)`)
+
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("cannot create migrations table: %w", err)
+	}
+
+	return db, nil
+}
+
+// Migrate applies migrations of a component that were not applied yet, each in its own transaction
+func Migrate(db *sql.DB, component string, migrations []string) error {
+	for i, m := range migrations {
+		version := i + 1
+
+		var applied int
+		err := db.QueryRow("SELECT COUNT(*) FROM schema_migrations WHERE component = ? AND version = ?", component, version).Scan(&applied)
+
+		if err != nil {
+			return err
+		}
+
+		if applied > 0 {
+			continue
+		}
+
+		tx, err := db.Begin()
+
+		if err != nil {
+			return err
+		}
+
+		if _, err := tx.Exec(m); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("migration %s/%d failed: %w", component, version, err)
+		}
+
+		if _, err := tx.Exec("INSERT INTO schema_migrations (component, version) VALUES (?, ?)", component, version); err != nil {
+			tx.Rollback()
+			return err
+		}
+
+		if err := tx.Commit(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -47,6 +47,48 @@ type Error struct {
 	Errmsg string `json:"errmsg"`
 }
 
+// Gateway defines model for Gateway.
+type Gateway struct {
+	// Gateway ID
+	GatewayId int32 `json:"gatewayId"`
+
+	// Gateway MAC
+	GatewayMac *string `json:"gatewayMac,omitempty"`
+
+	// Gateway name
+	GatewayName *string `json:"gatewayName,omitempty"`
+
+	// Gateway version:1-G1,2-G2,3-G3,4-G4
+	GatewayVersion *int32 `json:"gatewayVersion,omitempty"`
+
+	// Is online:0-No,1-Yes
+	IsOnline *int32 `json:"isOnline,omitempty"`
+
+	// Number of locks bound to the gateway
+	LockNum *int32 `json:"lockNum,omitempty"`
+
+	// Name of the network the gateway is connected to
+	NetworkName *string `json:"networkName,omitempty"`
+}
+
+// GatewayLock defines model for GatewayLock.
+type GatewayLock struct {
+	// Lock alias
+	LockAlias *string `json:"lockAlias,omitempty"`
+
+	// Lock ID
+	LockId int32 `json:"lockId"`
+
+	// Lock MAC
+	LockMac *string `json:"lockMac,omitempty"`
+
+	// Lock name
+	LockName *string `json:"lockName,omitempty"`
+
+	// Signal strength of the lock at the gateway
+	Rssi *int32 `json:"rssi,omitempty"`
+}
+
 // Lock defines model for Lock.
 type Lock struct {
 	// Lock init time (timestamp in millisecond)
@@ -58,12 +100,18 @@ type Lock struct {
 	// characteristic value. it is used to indicate what kinds of feature do a lock support.
 	FeatureValue *string `json:"featureValue,omitempty"`
 
+	// Firmware version
+	FirmwareRevision *string `json:"firmwareRevision,omitempty"`
+
 	// Group id
 	GroupId *int32 `json:"groupId,omitempty"`
 
 	// Group name
 	GroupName *string `json:"groupName,omitempty"`
 
+	// Hardware version
+	HardwareRevision *string `json:"hardwareRevision,omitempty"`
+
 	// Is the lock binded to gateway:1-yes, 0-no
 	HasGateway *int32 `json:"hasGateway,omitempty"`
 
@@ -81,6 +129,9 @@ type Lock struct {
 
 	// Lock name
 	LockName string `json:"lockName"`
+
+	// Product model number
+	ModelNum *string `json:"modelNum,omitempty"`
 }
 
 // LockOpenState defines model for LockOpenState.
@@ -89,6 +140,36 @@ type LockOpenState struct {
 	State *int32 `json:"state,omitempty"`
 }
 
+// LockRecord defines model for LockRecord.
+type LockRecord struct {
+	// Passcode, card number or fingerprint number used
+	KeyboardPwd *string `json:"keyboardPwd,omitempty"`
+
+	// Time the operation happened on the lock (timestamp in millisecond)
+	LockDate int64 `json:"lockDate"`
+
+	// Lock ID
+	LockId int32 `json:"lockId"`
+
+	// Record ID
+	RecordId *int64 `json:"recordId,omitempty"`
+
+	// Record type, refer to: Record type
+	RecordType int32 `json:"recordType"`
+
+	// Record type uploaded by the lock
+	RecordTypeFromLock *int32 `json:"recordTypeFromLock,omitempty"`
+
+	// Time the record was uploaded (timestamp in millisecond)
+	ServerDate *int64 `json:"serverDate,omitempty"`
+
+	// Is success:0-No,1-Yes
+	Success int32 `json:"success"`
+
+	// Username of the operator
+	Username *string `json:"username,omitempty"`
+}
+
 // PaginationInfo defines model for PaginationInfo.
 type PaginationInfo struct {
 	// Page no, start from 1
@@ -104,12 +185,156 @@ type PaginationInfo struct {
 	Total *int32 `json:"total,omitempty"`
 }
 
+// PassageModeConfig defines model for PassageModeConfig.
+type PassageModeConfig struct {
+	// Unlock when passage mode starts:1-Yes,2-No
+	AutoUnlock *int32 `json:"autoUnlock,omitempty"`
+
+	// End time in minutes from midnight
+	EndDate *int32 `json:"endDate,omitempty"`
+
+	// All day:1-Yes,2-No
+	IsAllDay *int32 `json:"isAllDay,omitempty"`
+
+	// Passage mode:1-on,2-off
+	PassageMode *int32 `json:"passageMode,omitempty"`
+
+	// Start time in minutes from midnight
+	StartDate *int32 `json:"startDate,omitempty"`
+
+	// Week days, 1-Monday to 7-Sunday
+	WeekDays *[]int32 `json:"weekDays,omitempty"`
+}
+
+// Passcode defines model for Passcode.
+type Passcode struct {
+	// End time (timestamp in millisecond)
+	EndDate *int64 `json:"endDate,omitempty"`
+
+	// Is custom passcode:0-No,1-Yes
+	IsCustom *int32 `json:"isCustom,omitempty"`
+
+	// Passcode
+	KeyboardPwd *string `json:"keyboardPwd,omitempty"`
+
+	// Passcode ID
+	KeyboardPwdId *int32 `json:"keyboardPwdId,omitempty"`
+
+	// Passcode name
+	KeyboardPwdName *string `json:"keyboardPwdName,omitempty"`
+
+	// Passcode type:1-one-time,2-permanent,3-period
+	KeyboardPwdType *int32 `json:"keyboardPwdType,omitempty"`
+
+	// Lock ID
+	LockId *int32 `json:"lockId,omitempty"`
+
+	// Time the passcode was issued (timestamp in millisecond)
+	SendDate *int64 `json:"sendDate,omitempty"`
+
+	// Username of the issuer
+	SenderUsername *string `json:"senderUsername,omitempty"`
+
+	// Start time (timestamp in millisecond)
+	StartDate *int64 `json:"startDate,omitempty"`
+}
+
 // AccessToken defines model for AccessToken.
 type AccessToken = string
 
 // ClientId defines model for ClientId.
 type ClientId = string
 
+// ListGatewaysParams defines parameters for ListGateways.
+type ListGatewaysParams struct {
+	// clientId from Create application
+	ClientId ClientId `form:"clientId" json:"clientId"`
+
+	// Access token，refer to: Get access token
+	AccessToken AccessToken `form:"accessToken" json:"accessToken"`
+
+	// Page no, start from 1
+	PageNo int32 `form:"pageNo" json:"pageNo"`
+
+	// Items per page, default 20, max 100
+	PageSize int32 `form:"pageSize" json:"pageSize"`
+
+	// Current time (timestamp in millisecond)
+	Date int64 `form:"date" json:"date"`
+}
+
+// ListGatewayLocksParams defines parameters for ListGatewayLocks.
+type ListGatewayLocksParams struct {
+	// clientId from Create application
+	ClientId ClientId `form:"clientId" json:"clientId"`
+
+	// Access token，refer to: Get access token
+	AccessToken AccessToken `form:"accessToken" json:"accessToken"`
+
+	// Gateway ID
+	GatewayId int32 `form:"gatewayId" json:"gatewayId"`
+
+	// Current time (timestamp in millisecond)
+	Date int64 `form:"date" json:"date"`
+}
+
+// GeneratePasscodeParams defines parameters for GeneratePasscode.
+type GeneratePasscodeParams struct {
+	// clientId from Create application
+	ClientId ClientId `form:"clientId" json:"clientId"`
+
+	// Access token，refer to: Get access token
+	AccessToken AccessToken `form:"accessToken" json:"accessToken"`
+
+	// Lock ID, generated by Lock init
+	LockId int32 `form:"lockId" json:"lockId"`
+
+	// Passcode type:1-one-time,2-permanent,3-period
+	KeyboardPwdType int32 `form:"keyboardPwdType" json:"keyboardPwdType"`
+
+	// Passcode name
+	KeyboardPwdName *string `form:"keyboardPwdName,omitempty" json:"keyboardPwdName,omitempty"`
+
+	// Start time (timestamp in millisecond)
+	StartDate int64 `form:"startDate" json:"startDate"`
+
+	// End time (timestamp in millisecond), required for period passcodes
+	EndDate *int64 `form:"endDate,omitempty" json:"endDate,omitempty"`
+
+	// Current time (timestamp in millisecond)
+	Date int64 `form:"date" json:"date"`
+}
+
+// GetLockDetailParams defines parameters for GetLockDetail.
+type GetLockDetailParams struct {
+	// clientId from Create application
+	ClientId ClientId `form:"clientId" json:"clientId"`
+
+	// Access token，refer to: Get access token
+	AccessToken AccessToken `form:"accessToken" json:"accessToken"`
+
+	// Lock ID, generated by Lock init
+	LockId int32 `form:"lockId" json:"lockId"`
+
+	// Current time (timestamp in millisecond)
+	Date int64 `form:"date" json:"date"`
+}
+
+// GetPassageModeConfigParams defines parameters for GetPassageModeConfig.
+type GetPassageModeConfigParams struct {
+	// clientId from Create application
+	ClientId ClientId `form:"clientId" json:"clientId"`
+
+	// Access token，refer to: Get access token
+	AccessToken AccessToken `form:"accessToken" json:"accessToken"`
+
+	// Lock ID, generated by Lock init
+	LockId int32 `form:"lockId" json:"lockId"`
+
+	// Current time (timestamp in millisecond)
+	Date int64 `form:"date" json:"date"`
+}
+
 // ListLocksParams defines parameters for ListLocks.
 type ListLocksParams struct {
 	// clientId from Create application
@@ -134,6 +359,27 @@ type ListLocksParams struct {
 	Date int64 `form:"date" json:"date"`
 }
 
+// ListPasscodesParams defines parameters for ListPasscodes.
+type ListPasscodesParams struct {
+	// clientId from Create application
+	ClientId ClientId `form:"clientId" json:"clientId"`
+
+	// Access token，refer to: Get access token
+	AccessToken AccessToken `form:"accessToken" json:"accessToken"`
+
+	// Lock ID, generated by Lock init
+	LockId int32 `form:"lockId" json:"lockId"`
+
+	// Page no, start from 1
+	PageNo int32 `form:"pageNo" json:"pageNo"`
+
+	// Items per page, default 20, max 100
+	PageSize int32 `form:"pageSize" json:"pageSize"`
+
+	// Current time (timestamp in millisecond)
+	Date int64 `form:"date" json:"date"`
+}
+
 // GetLockOpenStateParams defines parameters for GetLockOpenState.
 type GetLockOpenStateParams struct {
 	// clientId from Create application
@@ -149,6 +395,33 @@ type GetLockOpenStateParams struct {
 	Date int64 `form:"date" json:"date"`
 }
 
+// ListLockRecordsParams defines parameters for ListLockRecords.
+type ListLockRecordsParams struct {
+	// clientId from Create application
+	ClientId ClientId `form:"clientId" json:"clientId"`
+
+	// Access token，refer to: Get access token
+	AccessToken AccessToken `form:"accessToken" json:"accessToken"`
+
+	// Lock ID, generated by Lock init
+	LockId int32 `form:"lockId" json:"lockId"`
+
+	// Start time (timestamp in millisecond), 0 for no constraint
+	StartDate *int64 `form:"startDate,omitempty" json:"startDate,omitempty"`
+
+	// End time (timestamp in millisecond), 0 for no constraint
+	EndDate *int64 `form:"endDate,omitempty" json:"endDate,omitempty"`
+
+	// Page no, start from 1
+	PageNo int32 `form:"pageNo" json:"pageNo"`
+
+	// Items per page, default 20, max 100
+	PageSize int32 `form:"pageSize" json:"pageSize"`
+
+	// Current time (timestamp in millisecond)
+	Date int64 `form:"date" json:"date"`
+}
+
 // RequestEditorFn  is the function signature for the RequestEditor callback function
 type RequestEditorFn func(ctx context.Context, req *http.Request) error
 
@@ -225,9 +498,39 @@ type ClientInterface interface {
 	// GetToken request with any body
 	GetTokenWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// ListGateways request
+	ListGateways(ctx context.Context, params *ListGatewaysParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ListGatewayLocks request
+	ListGatewayLocks(ctx context.Context, params *ListGatewayLocksParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// AddPasscode request with any body
+	AddPasscodeWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ChangePasscode request with any body
+	ChangePasscodeWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DeletePasscode request with any body
+	DeletePasscodeWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GeneratePasscode request
+	GeneratePasscode(ctx context.Context, params *GeneratePasscodeParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ConfigPassageMode request with any body
+	ConfigPassageModeWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetLockDetail request
+	GetLockDetail(ctx context.Context, params *GetLockDetailParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetPassageModeConfig request
+	GetPassageModeConfig(ctx context.Context, params *GetPassageModeConfigParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// ListLocks request
 	ListLocks(ctx context.Context, params *ListLocksParams, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// ListPasscodes request
+	ListPasscodes(ctx context.Context, params *ListPasscodesParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// PostLock request with any body
 	PostLockWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
 
@@ -236,6 +539,9 @@ type ClientInterface interface {
 
 	// PostUnlock request with any body
 	PostUnlockWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ListLockRecords request
+	ListLockRecords(ctx context.Context, params *ListLockRecordsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
 }
 
 func (c *Client) GetTokenWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -250,8 +556,8 @@ func (c *Client) GetTokenWithBody(ctx context.Context, contentType string, body
 	return c.Client.Do(req)
 }
 
-func (c *Client) ListLocks(ctx context.Context, params *ListLocksParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewListLocksRequest(c.Server, params)
+func (c *Client) ListGateways(ctx context.Context, params *ListGatewaysParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListGatewaysRequest(c.Server, params)
 	if err != nil {
 		return nil, err
 	}
@@ -262,8 +568,8 @@ func (c *Client) ListLocks(ctx context.Context, params *ListLocksParams, reqEdit
 	return c.Client.Do(req)
 }
 
-func (c *Client) PostLockWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewPostLockRequestWithBody(c.Server, contentType, body)
+func (c *Client) ListGatewayLocks(ctx context.Context, params *ListGatewayLocksParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListGatewayLocksRequest(c.Server, params)
 	if err != nil {
 		return nil, err
 	}
@@ -274,8 +580,8 @@ func (c *Client) PostLockWithBody(ctx context.Context, contentType string, body
 	return c.Client.Do(req)
 }
 
-func (c *Client) GetLockOpenState(ctx context.Context, params *GetLockOpenStateParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewGetLockOpenStateRequest(c.Server, params)
+func (c *Client) AddPasscodeWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewAddPasscodeRequestWithBody(c.Server, contentType, body)
 	if err != nil {
 		return nil, err
 	}
@@ -286,8 +592,8 @@ func (c *Client) GetLockOpenState(ctx context.Context, params *GetLockOpenStateP
 	return c.Client.Do(req)
 }
 
-func (c *Client) PostUnlockWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewPostUnlockRequestWithBody(c.Server, contentType, body)
+func (c *Client) ChangePasscodeWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewChangePasscodeRequestWithBody(c.Server, contentType, body)
 	if err != nil {
 		return nil, err
 	}
@@ -298,110 +604,210 @@ func (c *Client) PostUnlockWithBody(ctx context.Context, contentType string, bod
 	return c.Client.Do(req)
 }
 
-// NewGetTokenRequestWithBody generates requests for GetToken with any type of body
-func NewGetTokenRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
-	var err error
-
-	serverURL, err := url.Parse(server)
+func (c *Client) DeletePasscodeWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeletePasscodeRequestWithBody(c.Server, contentType, body)
 	if err != nil {
 		return nil, err
 	}
-
-	operationPath := fmt.Sprintf("/oauth2/token")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	queryURL, err := serverURL.Parse(operationPath)
+func (c *Client) GeneratePasscode(ctx context.Context, params *GeneratePasscodeParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGeneratePasscodeRequest(c.Server, params)
 	if err != nil {
 		return nil, err
 	}
-
-	req, err := http.NewRequest("POST", queryURL.String(), body)
-	if err != nil {
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-
-	req.Header.Add("Content-Type", contentType)
-
-	return req, nil
+	return c.Client.Do(req)
 }
 
-// NewListLocksRequest generates requests for ListLocks
-func NewListLocksRequest(server string, params *ListLocksParams) (*http.Request, error) {
-	var err error
-
-	serverURL, err := url.Parse(server)
+func (c *Client) ConfigPassageModeWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewConfigPassageModeRequestWithBody(c.Server, contentType, body)
 	if err != nil {
 		return nil, err
 	}
-
-	operationPath := fmt.Sprintf("/v3/lock/list")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	queryURL, err := serverURL.Parse(operationPath)
+func (c *Client) GetLockDetail(ctx context.Context, params *GetLockDetailParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetLockDetailRequest(c.Server, params)
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	queryValues := queryURL.Query()
-
-	if queryFrag, err := runtime.StyleParamWithLocation("form", true, "clientId", runtime.ParamLocationQuery, params.ClientId); err != nil {
+func (c *Client) GetPassageModeConfig(ctx context.Context, params *GetPassageModeConfigParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetPassageModeConfigRequest(c.Server, params)
+	if err != nil {
 		return nil, err
-	} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
-	} else {
-		for k, v := range parsed {
-			for _, v2 := range v {
-				queryValues.Add(k, v2)
-			}
-		}
 	}
+	return c.Client.Do(req)
+}
 
-	if queryFrag, err := runtime.StyleParamWithLocation("form", true, "accessToken", runtime.ParamLocationQuery, params.AccessToken); err != nil {
+func (c *Client) ListLocks(ctx context.Context, params *ListLocksParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListLocksRequest(c.Server, params)
+	if err != nil {
 		return nil, err
-	} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
-	} else {
-		for k, v := range parsed {
-			for _, v2 := range v {
-				queryValues.Add(k, v2)
-			}
-		}
 	}
+	return c.Client.Do(req)
+}
 
-	if params.LockAlias != nil {
+func (c *Client) ListPasscodes(ctx context.Context, params *ListPasscodesParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListPasscodesRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "lockAlias", runtime.ParamLocationQuery, *params.LockAlias); err != nil {
-			return nil, err
-		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
-			return nil, err
-		} else {
-			for k, v := range parsed {
-				for _, v2 := range v {
-					queryValues.Add(k, v2)
-				}
-			}
-		}
+func (c *Client) PostLockWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostLockRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
+func (c *Client) GetLockOpenState(ctx context.Context, params *GetLockOpenStateParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetLockOpenStateRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	if params.GroupId != nil {
+func (c *Client) PostUnlockWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostUnlockRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "groupId", runtime.ParamLocationQuery, *params.GroupId); err != nil {
-			return nil, err
-		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
-			return nil, err
-		} else {
-			for k, v := range parsed {
-				for _, v2 := range v {
-					queryValues.Add(k, v2)
-				}
+func (c *Client) ListLockRecords(ctx context.Context, params *ListLockRecordsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListLockRecordsRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// NewGetTokenRequestWithBody generates requests for GetToken with any type of body
+func NewGetTokenRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/oauth2/token")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewListGatewaysRequest generates requests for ListGateways
+func NewListGatewaysRequest(server string, params *ListGatewaysParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v3/gateway/list")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	queryValues := queryURL.Query()
+
+	if queryFrag, err := runtime.StyleParamWithLocation("form", true, "clientId", runtime.ParamLocationQuery, params.ClientId); err != nil {
+		return nil, err
+	} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+		return nil, err
+	} else {
+		for k, v := range parsed {
+			for _, v2 := range v {
+				queryValues.Add(k, v2)
 			}
 		}
+	}
 
+	if queryFrag, err := runtime.StyleParamWithLocation("form", true, "accessToken", runtime.ParamLocationQuery, params.AccessToken); err != nil {
+		return nil, err
+	} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+		return nil, err
+	} else {
+		for k, v := range parsed {
+			for _, v2 := range v {
+				queryValues.Add(k, v2)
+			}
+		}
 	}
 
 	if queryFrag, err := runtime.StyleParamWithLocation("form", true, "pageNo", runtime.ParamLocationQuery, params.PageNo); err != nil {
@@ -450,37 +856,8 @@ func NewListLocksRequest(server string, params *ListLocksParams) (*http.Request,
 	return req, nil
 }
 
-// NewPostLockRequestWithBody generates requests for PostLock with any type of body
-func NewPostLockRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
-	var err error
-
-	serverURL, err := url.Parse(server)
-	if err != nil {
-		return nil, err
-	}
-
-	operationPath := fmt.Sprintf("/v3/lock/lock")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
-	}
-
-	queryURL, err := serverURL.Parse(operationPath)
-	if err != nil {
-		return nil, err
-	}
-
-	req, err := http.NewRequest("POST", queryURL.String(), body)
-	if err != nil {
-		return nil, err
-	}
-
-	req.Header.Add("Content-Type", contentType)
-
-	return req, nil
-}
-
-// NewGetLockOpenStateRequest generates requests for GetLockOpenState
-func NewGetLockOpenStateRequest(server string, params *GetLockOpenStateParams) (*http.Request, error) {
+// NewListGatewayLocksRequest generates requests for ListGatewayLocks
+func NewListGatewayLocksRequest(server string, params *ListGatewayLocksParams) (*http.Request, error) {
 	var err error
 
 	serverURL, err := url.Parse(server)
@@ -488,7 +865,7 @@ func NewGetLockOpenStateRequest(server string, params *GetLockOpenStateParams) (
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/v3/lock/queryOpenState")
+	operationPath := fmt.Sprintf("/v3/gateway/listLock")
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -524,7 +901,7 @@ func NewGetLockOpenStateRequest(server string, params *GetLockOpenStateParams) (
 		}
 	}
 
-	if queryFrag, err := runtime.StyleParamWithLocation("form", true, "lockId", runtime.ParamLocationQuery, params.LockId); err != nil {
+	if queryFrag, err := runtime.StyleParamWithLocation("form", true, "gatewayId", runtime.ParamLocationQuery, params.GatewayId); err != nil {
 		return nil, err
 	} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
 		return nil, err
@@ -558,8 +935,8 @@ func NewGetLockOpenStateRequest(server string, params *GetLockOpenStateParams) (
 	return req, nil
 }
 
-// NewPostUnlockRequestWithBody generates requests for PostUnlock with any type of body
-func NewPostUnlockRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+// NewAddPasscodeRequestWithBody generates requests for AddPasscode with any type of body
+func NewAddPasscodeRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
 	var err error
 
 	serverURL, err := url.Parse(server)
@@ -567,7 +944,7 @@ func NewPostUnlockRequestWithBody(server string, contentType string, body io.Rea
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/v3/lock/unlock")
+	operationPath := fmt.Sprintf("/v3/keyboardPwd/add")
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -587,73 +964,1182 @@ func NewPostUnlockRequestWithBody(server string, contentType string, body io.Rea
 	return req, nil
 }
 
-func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
-	for _, r := range c.RequestEditors {
-		if err := r(ctx, req); err != nil {
-			return err
-		}
+// NewChangePasscodeRequestWithBody generates requests for ChangePasscode with any type of body
+func NewChangePasscodeRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
 	}
-	for _, r := range additionalEditors {
-		if err := r(ctx, req); err != nil {
-			return err
-		}
+
+	operationPath := fmt.Sprintf("/v3/keyboardPwd/change")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
 	}
-	return nil
-}
 
-// ClientWithResponses builds on ClientInterface to offer response payloads
-type ClientWithResponses struct {
-	ClientInterface
-}
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
 
-// NewClientWithResponses creates a new ClientWithResponses, which wraps
-// Client with return type handling
-func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
-	client, err := NewClient(server, opts...)
+	req, err := http.NewRequest("POST", queryURL.String(), body)
 	if err != nil {
 		return nil, err
 	}
-	return &ClientWithResponses{client}, nil
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
 }
 
-// WithBaseURL overrides the baseURL.
-func WithBaseURL(baseURL string) ClientOption {
-	return func(c *Client) error {
-		newBaseURL, err := url.Parse(baseURL)
-		if err != nil {
-			return err
-		}
-		c.Server = newBaseURL.String()
-		return nil
+// NewDeletePasscodeRequestWithBody generates requests for DeletePasscode with any type of body
+func NewDeletePasscodeRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
 	}
-}
 
-// ClientWithResponsesInterface is the interface specification for the client with responses above.
-type ClientWithResponsesInterface interface {
-	// GetToken request with any body
-	GetTokenWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*GetTokenResponse, error)
+	operationPath := fmt.Sprintf("/v3/keyboardPwd/delete")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
 
-	// ListLocks request
-	ListLocksWithResponse(ctx context.Context, params *ListLocksParams, reqEditors ...RequestEditorFn) (*ListLocksResponse, error)
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
 
-	// PostLock request with any body
-	PostLockWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostLockResponse, error)
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
 
-	// GetLockOpenState request
-	GetLockOpenStateWithResponse(ctx context.Context, params *GetLockOpenStateParams, reqEditors ...RequestEditorFn) (*GetLockOpenStateResponse, error)
+	req.Header.Add("Content-Type", contentType)
 
-	// PostUnlock request with any body
-	PostUnlockWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostUnlockResponse, error)
+	return req, nil
 }
 
-type GetTokenResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON200      *interface{}
-}
+// NewGeneratePasscodeRequest generates requests for GeneratePasscode
+func NewGeneratePasscodeRequest(server string, params *GeneratePasscodeParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v3/keyboardPwd/get")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	queryValues := queryURL.Query()
+
+	if queryFrag, err := runtime.StyleParamWithLocation("form", true, "clientId", runtime.ParamLocationQuery, params.ClientId); err != nil {
+		return nil, err
+	} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+		return nil, err
+	} else {
+		for k, v := range parsed {
+			for _, v2 := range v {
+				queryValues.Add(k, v2)
+			}
+		}
+	}
+
+	if queryFrag, err := runtime.StyleParamWithLocation("form", true, "accessToken", runtime.ParamLocationQuery, params.AccessToken); err != nil {
+		return nil, err
+	} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+		return nil, err
+	} else {
+		for k, v := range parsed {
+			for _, v2 := range v {
+				queryValues.Add(k, v2)
+			}
+		}
+	}
+
+	if queryFrag, err := runtime.StyleParamWithLocation("form", true, "lockId", runtime.ParamLocationQuery, params.LockId); err != nil {
+		return nil, err
+	} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+		return nil, err
+	} else {
+		for k, v := range parsed {
+			for _, v2 := range v {
+				queryValues.Add(k, v2)
+			}
+		}
+	}
+
+	if queryFrag, err := runtime.StyleParamWithLocation("form", true, "keyboardPwdType", runtime.ParamLocationQuery, params.KeyboardPwdType); err != nil {
+		return nil, err
+	} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+		return nil, err
+	} else {
+		for k, v := range parsed {
+			for _, v2 := range v {
+				queryValues.Add(k, v2)
+			}
+		}
+	}
+
+	if params.KeyboardPwdName != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "keyboardPwdName", runtime.ParamLocationQuery, *params.KeyboardPwdName); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	if queryFrag, err := runtime.StyleParamWithLocation("form", true, "startDate", runtime.ParamLocationQuery, params.StartDate); err != nil {
+		return nil, err
+	} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+		return nil, err
+	} else {
+		for k, v := range parsed {
+			for _, v2 := range v {
+				queryValues.Add(k, v2)
+			}
+		}
+	}
+
+	if params.EndDate != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "endDate", runtime.ParamLocationQuery, *params.EndDate); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	if queryFrag, err := runtime.StyleParamWithLocation("form", true, "date", runtime.ParamLocationQuery, params.Date); err != nil {
+		return nil, err
+	} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+		return nil, err
+	} else {
+		for k, v := range parsed {
+			for _, v2 := range v {
+				queryValues.Add(k, v2)
+			}
+		}
+	}
+
+	queryURL.RawQuery = queryValues.Encode()
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewConfigPassageModeRequestWithBody generates requests for ConfigPassageMode with any type of body
+func NewConfigPassageModeRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v3/lock/configPassageMode")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetLockDetailRequest generates requests for GetLockDetail
+func NewGetLockDetailRequest(server string, params *GetLockDetailParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v3/lock/detail")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	queryValues := queryURL.Query()
+
+	if queryFrag, err := runtime.StyleParamWithLocation("form", true, "clientId", runtime.ParamLocationQuery, params.ClientId); err != nil {
+		return nil, err
+	} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+		return nil, err
+	} else {
+		for k, v := range parsed {
+			for _, v2 := range v {
+				queryValues.Add(k, v2)
+			}
+		}
+	}
+
+	if queryFrag, err := runtime.StyleParamWithLocation("form", true, "accessToken", runtime.ParamLocationQuery, params.AccessToken); err != nil {
+		return nil, err
+	} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+		return nil, err
+	} else {
+		for k, v := range parsed {
+			for _, v2 := range v {
+				queryValues.Add(k, v2)
+			}
+		}
+	}
+
+	if queryFrag, err := runtime.StyleParamWithLocation("form", true, "lockId", runtime.ParamLocationQuery, params.LockId); err != nil {
+		return nil, err
+	} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+		return nil, err
+	} else {
+		for k, v := range parsed {
+			for _, v2 := range v {
+				queryValues.Add(k, v2)
+			}
+		}
+	}
+
+	if queryFrag, err := runtime.StyleParamWithLocation("form", true, "date", runtime.ParamLocationQuery, params.Date); err != nil {
+		return nil, err
+	} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+		return nil, err
+	} else {
+		for k, v := range parsed {
+			for _, v2 := range v {
+				queryValues.Add(k, v2)
+			}
+		}
+	}
+
+	queryURL.RawQuery = queryValues.Encode()
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetPassageModeConfigRequest generates requests for GetPassageModeConfig
+func NewGetPassageModeConfigRequest(server string, params *GetPassageModeConfigParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v3/lock/getPassageModeConfig")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	queryValues := queryURL.Query()
+
+	if queryFrag, err := runtime.StyleParamWithLocation("form", true, "clientId", runtime.ParamLocationQuery, params.ClientId); err != nil {
+		return nil, err
+	} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+		return nil, err
+	} else {
+		for k, v := range parsed {
+			for _, v2 := range v {
+				queryValues.Add(k, v2)
+			}
+		}
+	}
+
+	if queryFrag, err := runtime.StyleParamWithLocation("form", true, "accessToken", runtime.ParamLocationQuery, params.AccessToken); err != nil {
+		return nil, err
+	} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+		return nil, err
+	} else {
+		for k, v := range parsed {
+			for _, v2 := range v {
+				queryValues.Add(k, v2)
+			}
+		}
+	}
+
+	if queryFrag, err := runtime.StyleParamWithLocation("form", true, "lockId", runtime.ParamLocationQuery, params.LockId); err != nil {
+		return nil, err
+	} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+		return nil, err
+	} else {
+		for k, v := range parsed {
+			for _, v2 := range v {
+				queryValues.Add(k, v2)
+			}
+		}
+	}
+
+	if queryFrag, err := runtime.StyleParamWithLocation("form", true, "date", runtime.ParamLocationQuery, params.Date); err != nil {
+		return nil, err
+	} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+		return nil, err
+	} else {
+		for k, v := range parsed {
+			for _, v2 := range v {
+				queryValues.Add(k, v2)
+			}
+		}
+	}
+
+	queryURL.RawQuery = queryValues.Encode()
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewListLocksRequest generates requests for ListLocks
+func NewListLocksRequest(server string, params *ListLocksParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v3/lock/list")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	queryValues := queryURL.Query()
+
+	if queryFrag, err := runtime.StyleParamWithLocation("form", true, "clientId", runtime.ParamLocationQuery, params.ClientId); err != nil {
+		return nil, err
+	} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+		return nil, err
+	} else {
+		for k, v := range parsed {
+			for _, v2 := range v {
+				queryValues.Add(k, v2)
+			}
+		}
+	}
+
+	if queryFrag, err := runtime.StyleParamWithLocation("form", true, "accessToken", runtime.ParamLocationQuery, params.AccessToken); err != nil {
+		return nil, err
+	} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+		return nil, err
+	} else {
+		for k, v := range parsed {
+			for _, v2 := range v {
+				queryValues.Add(k, v2)
+			}
+		}
+	}
+
+	if params.LockAlias != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "lockAlias", runtime.ParamLocationQuery, *params.LockAlias); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	if params.GroupId != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "groupId", runtime.ParamLocationQuery, *params.GroupId); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	if queryFrag, err := runtime.StyleParamWithLocation("form", true, "pageNo", runtime.ParamLocationQuery, params.PageNo); err != nil {
+		return nil, err
+	} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+		return nil, err
+	} else {
+		for k, v := range parsed {
+			for _, v2 := range v {
+				queryValues.Add(k, v2)
+			}
+		}
+	}
+
+	if queryFrag, err := runtime.StyleParamWithLocation("form", true, "pageSize", runtime.ParamLocationQuery, params.PageSize); err != nil {
+		return nil, err
+	} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+		return nil, err
+	} else {
+		for k, v := range parsed {
+			for _, v2 := range v {
+				queryValues.Add(k, v2)
+			}
+		}
+	}
+
+	if queryFrag, err := runtime.StyleParamWithLocation("form", true, "date", runtime.ParamLocationQuery, params.Date); err != nil {
+		return nil, err
+	} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+		return nil, err
+	} else {
+		for k, v := range parsed {
+			for _, v2 := range v {
+				queryValues.Add(k, v2)
+			}
+		}
+	}
+
+	queryURL.RawQuery = queryValues.Encode()
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewListPasscodesRequest generates requests for ListPasscodes
+func NewListPasscodesRequest(server string, params *ListPasscodesParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v3/lock/listKeyboardPwd")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	queryValues := queryURL.Query()
+
+	if queryFrag, err := runtime.StyleParamWithLocation("form", true, "clientId", runtime.ParamLocationQuery, params.ClientId); err != nil {
+		return nil, err
+	} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+		return nil, err
+	} else {
+		for k, v := range parsed {
+			for _, v2 := range v {
+				queryValues.Add(k, v2)
+			}
+		}
+	}
+
+	if queryFrag, err := runtime.StyleParamWithLocation("form", true, "accessToken", runtime.ParamLocationQuery, params.AccessToken); err != nil {
+		return nil, err
+	} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+		return nil, err
+	} else {
+		for k, v := range parsed {
+			for _, v2 := range v {
+				queryValues.Add(k, v2)
+			}
+		}
+	}
+
+	if queryFrag, err := runtime.StyleParamWithLocation("form", true, "lockId", runtime.ParamLocationQuery, params.LockId); err != nil {
+		return nil, err
+	} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+		return nil, err
+	} else {
+		for k, v := range parsed {
+			for _, v2 := range v {
+				queryValues.Add(k, v2)
+			}
+		}
+	}
+
+	if queryFrag, err := runtime.StyleParamWithLocation("form", true, "pageNo", runtime.ParamLocationQuery, params.PageNo); err != nil {
+		return nil, err
+	} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+		return nil, err
+	} else {
+		for k, v := range parsed {
+			for _, v2 := range v {
+				queryValues.Add(k, v2)
+			}
+		}
+	}
+
+	if queryFrag, err := runtime.StyleParamWithLocation("form", true, "pageSize", runtime.ParamLocationQuery, params.PageSize); err != nil {
+		return nil, err
+	} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+		return nil, err
+	} else {
+		for k, v := range parsed {
+			for _, v2 := range v {
+				queryValues.Add(k, v2)
+			}
+		}
+	}
+
+	if queryFrag, err := runtime.StyleParamWithLocation("form", true, "date", runtime.ParamLocationQuery, params.Date); err != nil {
+		return nil, err
+	} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+		return nil, err
+	} else {
+		for k, v := range parsed {
+			for _, v2 := range v {
+				queryValues.Add(k, v2)
+			}
+		}
+	}
+
+	queryURL.RawQuery = queryValues.Encode()
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostLockRequestWithBody generates requests for PostLock with any type of body
+func NewPostLockRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v3/lock/lock")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetLockOpenStateRequest generates requests for GetLockOpenState
+func NewGetLockOpenStateRequest(server string, params *GetLockOpenStateParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v3/lock/queryOpenState")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	queryValues := queryURL.Query()
+
+	if queryFrag, err := runtime.StyleParamWithLocation("form", true, "clientId", runtime.ParamLocationQuery, params.ClientId); err != nil {
+		return nil, err
+	} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+		return nil, err
+	} else {
+		for k, v := range parsed {
+			for _, v2 := range v {
+				queryValues.Add(k, v2)
+			}
+		}
+	}
+
+	if queryFrag, err := runtime.StyleParamWithLocation("form", true, "accessToken", runtime.ParamLocationQuery, params.AccessToken); err != nil {
+		return nil, err
+	} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+		return nil, err
+	} else {
+		for k, v := range parsed {
+			for _, v2 := range v {
+				queryValues.Add(k, v2)
+			}
+		}
+	}
+
+	if queryFrag, err := runtime.StyleParamWithLocation("form", true, "lockId", runtime.ParamLocationQuery, params.LockId); err != nil {
+		return nil, err
+	} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+		return nil, err
+	} else {
+		for k, v := range parsed {
+			for _, v2 := range v {
+				queryValues.Add(k, v2)
+			}
+		}
+	}
+
+	if queryFrag, err := runtime.StyleParamWithLocation("form", true, "date", runtime.ParamLocationQuery, params.Date); err != nil {
+		return nil, err
+	} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+		return nil, err
+	} else {
+		for k, v := range parsed {
+			for _, v2 := range v {
+				queryValues.Add(k, v2)
+			}
+		}
+	}
+
+	queryURL.RawQuery = queryValues.Encode()
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostUnlockRequestWithBody generates requests for PostUnlock with any type of body
+func NewPostUnlockRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v3/lock/unlock")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewListLockRecordsRequest generates requests for ListLockRecords
+func NewListLockRecordsRequest(server string, params *ListLockRecordsParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v3/lockRecord/list")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	queryValues := queryURL.Query()
+
+	if queryFrag, err := runtime.StyleParamWithLocation("form", true, "clientId", runtime.ParamLocationQuery, params.ClientId); err != nil {
+		return nil, err
+	} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+		return nil, err
+	} else {
+		for k, v := range parsed {
+			for _, v2 := range v {
+				queryValues.Add(k, v2)
+			}
+		}
+	}
+
+	if queryFrag, err := runtime.StyleParamWithLocation("form", true, "accessToken", runtime.ParamLocationQuery, params.AccessToken); err != nil {
+		return nil, err
+	} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+		return nil, err
+	} else {
+		for k, v := range parsed {
+			for _, v2 := range v {
+				queryValues.Add(k, v2)
+			}
+		}
+	}
+
+	if queryFrag, err := runtime.StyleParamWithLocation("form", true, "lockId", runtime.ParamLocationQuery, params.LockId); err != nil {
+		return nil, err
+	} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+		return nil, err
+	} else {
+		for k, v := range parsed {
+			for _, v2 := range v {
+				queryValues.Add(k, v2)
+			}
+		}
+	}
+
+	if params.StartDate != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "startDate", runtime.ParamLocationQuery, *params.StartDate); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	if params.EndDate != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "endDate", runtime.ParamLocationQuery, *params.EndDate); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	if queryFrag, err := runtime.StyleParamWithLocation("form", true, "pageNo", runtime.ParamLocationQuery, params.PageNo); err != nil {
+		return nil, err
+	} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+		return nil, err
+	} else {
+		for k, v := range parsed {
+			for _, v2 := range v {
+				queryValues.Add(k, v2)
+			}
+		}
+	}
+
+	if queryFrag, err := runtime.StyleParamWithLocation("form", true, "pageSize", runtime.ParamLocationQuery, params.PageSize); err != nil {
+		return nil, err
+	} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+		return nil, err
+	} else {
+		for k, v := range parsed {
+			for _, v2 := range v {
+				queryValues.Add(k, v2)
+			}
+		}
+	}
+
+	if queryFrag, err := runtime.StyleParamWithLocation("form", true, "date", runtime.ParamLocationQuery, params.Date); err != nil {
+		return nil, err
+	} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+		return nil, err
+	} else {
+		for k, v := range parsed {
+			for _, v2 := range v {
+				queryValues.Add(k, v2)
+			}
+		}
+	}
+
+	queryURL.RawQuery = queryValues.Encode()
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
+	for _, r := range c.RequestEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	for _, r := range additionalEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ClientWithResponses builds on ClientInterface to offer response payloads
+type ClientWithResponses struct {
+	ClientInterface
+}
+
+// NewClientWithResponses creates a new ClientWithResponses, which wraps
+// Client with return type handling
+func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
+	client, err := NewClient(server, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientWithResponses{client}, nil
+}
+
+// WithBaseURL overrides the baseURL.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) error {
+		newBaseURL, err := url.Parse(baseURL)
+		if err != nil {
+			return err
+		}
+		c.Server = newBaseURL.String()
+		return nil
+	}
+}
+
+// ClientWithResponsesInterface is the interface specification for the client with responses above.
+type ClientWithResponsesInterface interface {
+	// GetToken request with any body
+	GetTokenWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*GetTokenResponse, error)
+
+	// ListGateways request
+	ListGatewaysWithResponse(ctx context.Context, params *ListGatewaysParams, reqEditors ...RequestEditorFn) (*ListGatewaysResponse, error)
+
+	// ListGatewayLocks request
+	ListGatewayLocksWithResponse(ctx context.Context, params *ListGatewayLocksParams, reqEditors ...RequestEditorFn) (*ListGatewayLocksResponse, error)
+
+	// AddPasscode request with any body
+	AddPasscodeWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*AddPasscodeResponse, error)
+
+	// ChangePasscode request with any body
+	ChangePasscodeWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ChangePasscodeResponse, error)
+
+	// DeletePasscode request with any body
+	DeletePasscodeWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*DeletePasscodeResponse, error)
+
+	// GeneratePasscode request
+	GeneratePasscodeWithResponse(ctx context.Context, params *GeneratePasscodeParams, reqEditors ...RequestEditorFn) (*GeneratePasscodeResponse, error)
+
+	// ConfigPassageMode request with any body
+	ConfigPassageModeWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ConfigPassageModeResponse, error)
+
+	// GetLockDetail request
+	GetLockDetailWithResponse(ctx context.Context, params *GetLockDetailParams, reqEditors ...RequestEditorFn) (*GetLockDetailResponse, error)
+
+	// GetPassageModeConfig request
+	GetPassageModeConfigWithResponse(ctx context.Context, params *GetPassageModeConfigParams, reqEditors ...RequestEditorFn) (*GetPassageModeConfigResponse, error)
+
+	// ListLocks request
+	ListLocksWithResponse(ctx context.Context, params *ListLocksParams, reqEditors ...RequestEditorFn) (*ListLocksResponse, error)
+
+	// ListPasscodes request
+	ListPasscodesWithResponse(ctx context.Context, params *ListPasscodesParams, reqEditors ...RequestEditorFn) (*ListPasscodesResponse, error)
+
+	// PostLock request with any body
+	PostLockWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostLockResponse, error)
+
+	// GetLockOpenState request
+	GetLockOpenStateWithResponse(ctx context.Context, params *GetLockOpenStateParams, reqEditors ...RequestEditorFn) (*GetLockOpenStateResponse, error)
+
+	// PostUnlock request with any body
+	PostUnlockWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostUnlockResponse, error)
+
+	// ListLockRecords request
+	ListLockRecordsWithResponse(ctx context.Context, params *ListLockRecordsParams, reqEditors ...RequestEditorFn) (*ListLockRecordsResponse, error)
+}
+
+type GetTokenResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *interface{}
+}
+
+// Status returns HTTPResponse.Status
+func (r GetTokenResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetTokenResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListGatewaysResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *interface{}
+}
+
+// Status returns HTTPResponse.Status
+func (r ListGatewaysResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListGatewaysResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListGatewayLocksResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *interface{}
+}
+
+// Status returns HTTPResponse.Status
+func (r ListGatewayLocksResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListGatewayLocksResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type AddPasscodeResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *interface{}
+}
+
+// Status returns HTTPResponse.Status
+func (r AddPasscodeResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r AddPasscodeResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ChangePasscodeResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *interface{}
+}
+
+// Status returns HTTPResponse.Status
+func (r ChangePasscodeResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ChangePasscodeResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeletePasscodeResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *interface{}
+}
+
+// Status returns HTTPResponse.Status
+func (r DeletePasscodeResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeletePasscodeResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GeneratePasscodeResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *interface{}
+}
+
+// Status returns HTTPResponse.Status
+func (r GeneratePasscodeResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GeneratePasscodeResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ConfigPassageModeResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *interface{}
+}
+
+// Status returns HTTPResponse.Status
+func (r ConfigPassageModeResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ConfigPassageModeResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetLockDetailResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *interface{}
+}
+
+// Status returns HTTPResponse.Status
+func (r GetLockDetailResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetLockDetailResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetPassageModeConfigResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *interface{}
+}
 
 // Status returns HTTPResponse.Status
-func (r GetTokenResponse) Status() string {
+func (r GetPassageModeConfigResponse) Status() string {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.Status
 	}
@@ -661,7 +2147,7 @@ func (r GetTokenResponse) Status() string {
 }
 
 // StatusCode returns HTTPResponse.StatusCode
-func (r GetTokenResponse) StatusCode() int {
+func (r GetPassageModeConfigResponse) StatusCode() int {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.StatusCode
 	}
@@ -679,137 +2165,514 @@ func (r ListLocksResponse) Status() string {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.Status
 	}
-	return http.StatusText(0)
-}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListLocksResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListPasscodesResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *interface{}
+}
+
+// Status returns HTTPResponse.Status
+func (r ListPasscodesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListPasscodesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostLockResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *interface{}
+}
+
+// Status returns HTTPResponse.Status
+func (r PostLockResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostLockResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetLockOpenStateResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *interface{}
+}
+
+// Status returns HTTPResponse.Status
+func (r GetLockOpenStateResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetLockOpenStateResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostUnlockResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *interface{}
+}
+
+// Status returns HTTPResponse.Status
+func (r PostUnlockResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostUnlockResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListLockRecordsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *interface{}
+}
+
+// Status returns HTTPResponse.Status
+func (r ListLockRecordsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListLockRecordsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// GetTokenWithBodyWithResponse request with arbitrary body returning *GetTokenResponse
+func (c *ClientWithResponses) GetTokenWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*GetTokenResponse, error) {
+	rsp, err := c.GetTokenWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetTokenResponse(rsp)
+}
+
+// ListGatewaysWithResponse request returning *ListGatewaysResponse
+func (c *ClientWithResponses) ListGatewaysWithResponse(ctx context.Context, params *ListGatewaysParams, reqEditors ...RequestEditorFn) (*ListGatewaysResponse, error) {
+	rsp, err := c.ListGateways(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListGatewaysResponse(rsp)
+}
+
+// ListGatewayLocksWithResponse request returning *ListGatewayLocksResponse
+func (c *ClientWithResponses) ListGatewayLocksWithResponse(ctx context.Context, params *ListGatewayLocksParams, reqEditors ...RequestEditorFn) (*ListGatewayLocksResponse, error) {
+	rsp, err := c.ListGatewayLocks(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListGatewayLocksResponse(rsp)
+}
+
+// AddPasscodeWithBodyWithResponse request with arbitrary body returning *AddPasscodeResponse
+func (c *ClientWithResponses) AddPasscodeWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*AddPasscodeResponse, error) {
+	rsp, err := c.AddPasscodeWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAddPasscodeResponse(rsp)
+}
+
+// ChangePasscodeWithBodyWithResponse request with arbitrary body returning *ChangePasscodeResponse
+func (c *ClientWithResponses) ChangePasscodeWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ChangePasscodeResponse, error) {
+	rsp, err := c.ChangePasscodeWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseChangePasscodeResponse(rsp)
+}
+
+// DeletePasscodeWithBodyWithResponse request with arbitrary body returning *DeletePasscodeResponse
+func (c *ClientWithResponses) DeletePasscodeWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*DeletePasscodeResponse, error) {
+	rsp, err := c.DeletePasscodeWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeletePasscodeResponse(rsp)
+}
+
+// GeneratePasscodeWithResponse request returning *GeneratePasscodeResponse
+func (c *ClientWithResponses) GeneratePasscodeWithResponse(ctx context.Context, params *GeneratePasscodeParams, reqEditors ...RequestEditorFn) (*GeneratePasscodeResponse, error) {
+	rsp, err := c.GeneratePasscode(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGeneratePasscodeResponse(rsp)
+}
+
+// ConfigPassageModeWithBodyWithResponse request with arbitrary body returning *ConfigPassageModeResponse
+func (c *ClientWithResponses) ConfigPassageModeWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ConfigPassageModeResponse, error) {
+	rsp, err := c.ConfigPassageModeWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseConfigPassageModeResponse(rsp)
+}
+
+// GetLockDetailWithResponse request returning *GetLockDetailResponse
+func (c *ClientWithResponses) GetLockDetailWithResponse(ctx context.Context, params *GetLockDetailParams, reqEditors ...RequestEditorFn) (*GetLockDetailResponse, error) {
+	rsp, err := c.GetLockDetail(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetLockDetailResponse(rsp)
+}
+
+// GetPassageModeConfigWithResponse request returning *GetPassageModeConfigResponse
+func (c *ClientWithResponses) GetPassageModeConfigWithResponse(ctx context.Context, params *GetPassageModeConfigParams, reqEditors ...RequestEditorFn) (*GetPassageModeConfigResponse, error) {
+	rsp, err := c.GetPassageModeConfig(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetPassageModeConfigResponse(rsp)
+}
+
+// ListLocksWithResponse request returning *ListLocksResponse
+func (c *ClientWithResponses) ListLocksWithResponse(ctx context.Context, params *ListLocksParams, reqEditors ...RequestEditorFn) (*ListLocksResponse, error) {
+	rsp, err := c.ListLocks(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListLocksResponse(rsp)
+}
+
+// ListPasscodesWithResponse request returning *ListPasscodesResponse
+func (c *ClientWithResponses) ListPasscodesWithResponse(ctx context.Context, params *ListPasscodesParams, reqEditors ...RequestEditorFn) (*ListPasscodesResponse, error) {
+	rsp, err := c.ListPasscodes(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListPasscodesResponse(rsp)
+}
+
+// PostLockWithBodyWithResponse request with arbitrary body returning *PostLockResponse
+func (c *ClientWithResponses) PostLockWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostLockResponse, error) {
+	rsp, err := c.PostLockWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostLockResponse(rsp)
+}
+
+// GetLockOpenStateWithResponse request returning *GetLockOpenStateResponse
+func (c *ClientWithResponses) GetLockOpenStateWithResponse(ctx context.Context, params *GetLockOpenStateParams, reqEditors ...RequestEditorFn) (*GetLockOpenStateResponse, error) {
+	rsp, err := c.GetLockOpenState(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetLockOpenStateResponse(rsp)
+}
+
+// PostUnlockWithBodyWithResponse request with arbitrary body returning *PostUnlockResponse
+func (c *ClientWithResponses) PostUnlockWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostUnlockResponse, error) {
+	rsp, err := c.PostUnlockWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostUnlockResponse(rsp)
+}
+
+// ListLockRecordsWithResponse request returning *ListLockRecordsResponse
+func (c *ClientWithResponses) ListLockRecordsWithResponse(ctx context.Context, params *ListLockRecordsParams, reqEditors ...RequestEditorFn) (*ListLockRecordsResponse, error) {
+	rsp, err := c.ListLockRecords(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListLockRecordsResponse(rsp)
+}
+
+// ParseGetTokenResponse parses an HTTP response from a GetTokenWithResponse call
+func ParseGetTokenResponse(rsp *http.Response) (*GetTokenResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetTokenResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest interface{}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseListGatewaysResponse parses an HTTP response from a ListGatewaysWithResponse call
+func ParseListGatewaysResponse(rsp *http.Response) (*ListGatewaysResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListGatewaysResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest interface{}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r ListLocksResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
 	}
-	return 0
-}
 
-type PostLockResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON200      *interface{}
+	return response, nil
 }
 
-// Status returns HTTPResponse.Status
-func (r PostLockResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+// ParseListGatewayLocksResponse parses an HTTP response from a ListGatewayLocksWithResponse call
+func ParseListGatewayLocksResponse(rsp *http.Response) (*ListGatewayLocksResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
-}
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r PostLockResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+	response := &ListGatewayLocksResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
 	}
-	return 0
-}
 
-type GetLockOpenStateResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON200      *interface{}
-}
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest interface{}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
 
-// Status returns HTTPResponse.Status
-func (r GetLockOpenStateResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
 	}
-	return http.StatusText(0)
-}
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r GetLockOpenStateResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
-	}
-	return 0
+	return response, nil
 }
 
-type PostUnlockResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON200      *interface{}
-}
+// ParseAddPasscodeResponse parses an HTTP response from a AddPasscodeWithResponse call
+func ParseAddPasscodeResponse(rsp *http.Response) (*AddPasscodeResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
 
-// Status returns HTTPResponse.Status
-func (r PostUnlockResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+	response := &AddPasscodeResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
 	}
-	return http.StatusText(0)
-}
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r PostUnlockResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest interface{}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
 	}
-	return 0
+
+	return response, nil
 }
 
-// GetTokenWithBodyWithResponse request with arbitrary body returning *GetTokenResponse
-func (c *ClientWithResponses) GetTokenWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*GetTokenResponse, error) {
-	rsp, err := c.GetTokenWithBody(ctx, contentType, body, reqEditors...)
+// ParseChangePasscodeResponse parses an HTTP response from a ChangePasscodeWithResponse call
+func ParseChangePasscodeResponse(rsp *http.Response) (*ChangePasscodeResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
-	return ParseGetTokenResponse(rsp)
+
+	response := &ChangePasscodeResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest interface{}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
 }
 
-// ListLocksWithResponse request returning *ListLocksResponse
-func (c *ClientWithResponses) ListLocksWithResponse(ctx context.Context, params *ListLocksParams, reqEditors ...RequestEditorFn) (*ListLocksResponse, error) {
-	rsp, err := c.ListLocks(ctx, params, reqEditors...)
+// ParseDeletePasscodeResponse parses an HTTP response from a DeletePasscodeWithResponse call
+func ParseDeletePasscodeResponse(rsp *http.Response) (*DeletePasscodeResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
-	return ParseListLocksResponse(rsp)
+
+	response := &DeletePasscodeResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest interface{}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
 }
 
-// PostLockWithBodyWithResponse request with arbitrary body returning *PostLockResponse
-func (c *ClientWithResponses) PostLockWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostLockResponse, error) {
-	rsp, err := c.PostLockWithBody(ctx, contentType, body, reqEditors...)
+// ParseGeneratePasscodeResponse parses an HTTP response from a GeneratePasscodeWithResponse call
+func ParseGeneratePasscodeResponse(rsp *http.Response) (*GeneratePasscodeResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
-	return ParsePostLockResponse(rsp)
+
+	response := &GeneratePasscodeResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest interface{}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
 }
 
-// GetLockOpenStateWithResponse request returning *GetLockOpenStateResponse
-func (c *ClientWithResponses) GetLockOpenStateWithResponse(ctx context.Context, params *GetLockOpenStateParams, reqEditors ...RequestEditorFn) (*GetLockOpenStateResponse, error) {
-	rsp, err := c.GetLockOpenState(ctx, params, reqEditors...)
+// ParseConfigPassageModeResponse parses an HTTP response from a ConfigPassageModeWithResponse call
+func ParseConfigPassageModeResponse(rsp *http.Response) (*ConfigPassageModeResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
-	return ParseGetLockOpenStateResponse(rsp)
+
+	response := &ConfigPassageModeResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest interface{}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
 }
 
-// PostUnlockWithBodyWithResponse request with arbitrary body returning *PostUnlockResponse
-func (c *ClientWithResponses) PostUnlockWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostUnlockResponse, error) {
-	rsp, err := c.PostUnlockWithBody(ctx, contentType, body, reqEditors...)
+// ParseGetLockDetailResponse parses an HTTP response from a GetLockDetailWithResponse call
+func ParseGetLockDetailResponse(rsp *http.Response) (*GetLockDetailResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
-	return ParsePostUnlockResponse(rsp)
+
+	response := &GetLockDetailResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest interface{}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
 }
 
-// ParseGetTokenResponse parses an HTTP response from a GetTokenWithResponse call
-func ParseGetTokenResponse(rsp *http.Response) (*GetTokenResponse, error) {
+// ParseGetPassageModeConfigResponse parses an HTTP response from a GetPassageModeConfigWithResponse call
+func ParseGetPassageModeConfigResponse(rsp *http.Response) (*GetPassageModeConfigResponse, error) {
 	bodyBytes, err := ioutil.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &GetTokenResponse{
+	response := &GetPassageModeConfigResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
@@ -853,6 +2716,32 @@ func ParseListLocksResponse(rsp *http.Response) (*ListLocksResponse, error) {
 	return response, nil
 }
 
+// ParseListPasscodesResponse parses an HTTP response from a ListPasscodesWithResponse call
+func ParseListPasscodesResponse(rsp *http.Response) (*ListPasscodesResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListPasscodesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest interface{}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
 // ParsePostLockResponse parses an HTTP response from a PostLockWithResponse call
 func ParsePostLockResponse(rsp *http.Response) (*PostLockResponse, error) {
 	bodyBytes, err := ioutil.ReadAll(rsp.Body)
@@ -930,3 +2819,29 @@ func ParsePostUnlockResponse(rsp *http.Response) (*PostUnlockResponse, error) {
 
 	return response, nil
 }
+
+// ParseListLockRecordsResponse parses an HTTP response from a ListLockRecordsWithResponse call
+func ParseListLockRecordsResponse(rsp *http.Response) (*ListLockRecordsResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListLockRecordsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest interface{}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
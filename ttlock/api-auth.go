@@ -54,7 +54,8 @@ func (s *TTLockAPIService) Login(username string, password string) (Credentials,
 	return cred, nil
 }
 
-func (s *TTLockAPIService) refreshToken(cred *Credentials) error {
+// RefreshToken exchanges the refresh token for a new access token
+func (s *TTLockAPIService) RefreshToken(cred Credentials) (Credentials, error) {
 	// Get Refresh Token
 	data := url.Values{}
 	data.Add("clientId", s.clientID)
@@ -65,39 +66,78 @@ func (s *TTLockAPIService) refreshToken(cred *Credentials) error {
 	response, err := s.ttlockClient.GetTokenWithBodyWithResponse(context.TODO(), "application/x-www-form-urlencoded", strings.NewReader(data.Encode()))
 
 	if err != nil {
-		return err
+		return cred, err
 	}
 
 	var errorResponse ttlockapi.Error
 
 	err = json.Unmarshal(response.Body, &errorResponse)
 	if err == nil && errorResponse.Errmsg != "" {
-		return fmt.Errorf(errorResponse.Errmsg)
+		return cred, newAPIError(errorResponse)
 	}
 
 	var credentialsResponse ttlockapi.Credentials
 	err = json.Unmarshal(response.Body, &credentialsResponse)
 	if err != nil {
-		return err
+		return cred, err
 	}
 
-	if credentialsResponse.AccessToken != "" || credentialsResponse.RefreshToken != "" {
-		return fmt.Errorf("credentials not present in response")
+	if credentialsResponse.AccessToken == "" || credentialsResponse.RefreshToken == "" {
+		return cred, fmt.Errorf("credentials not present in response")
 	}
 
 	if cred.ID != credentialsResponse.Uid {
-		return fmt.Errorf("credentials refresh mismatch")
+		return cred, fmt.Errorf("credentials refresh mismatch")
 
 	}
 
 	cred.AccessToken = credentialsResponse.AccessToken
 	cred.RefreshToken = credentialsResponse.RefreshToken
 	cred.ExpiresAt = time.Now().Add(time.Duration(credentialsResponse.ExpiresIn) * time.Second)
+	cred.NeedsLogin = false
 
+	return cred, nil
+}
+
+// token returns credentials with a valid access token, using the token source if configured
+func (s *TTLockAPIService) token(cred *Credentials) error {
+	if s.tokenSource == nil {
+		return nil
+	}
+
+	c, err := s.tokenSource.Token(*cred)
+
+	if err != nil {
+		return err
+	}
+
+	*cred = c
+	return nil
+}
+
+func (s *TTLockAPIService) refreshToken(cred *Credentials) error {
+	var c Credentials
+	var err error
+
+	if s.tokenSource != nil {
+		c, err = s.tokenSource.Refresh(*cred)
+	} else {
+		c, err = s.RefreshToken(*cred)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	*cred = c
 	return nil
 }
 
 func (s *TTLockAPIService) autoAuth(cred *Credentials, fn func(string, string) (interface{}, error), getBody func(interface{}) []byte, retryCount int) (interface{}, error) {
+	if err := s.token(cred); err != nil {
+		return nil, fmt.Errorf("no valid token: %w", err)
+	}
+
 	response, err := fn(s.clientID, cred.AccessToken)
 
 	if err != nil {
@@ -114,8 +154,8 @@ func (s *TTLockAPIService) autoAuth(cred *Credentials, fn func(string, string) (
 		return response, err
 	}
 
-	// If token refresh error
-	if *errorResponse.Errcode == 10003 {
+	// If token invalid or expired
+	if *errorResponse.Errcode == 10003 || *errorResponse.Errcode == 10004 {
 		// Do token refresh
 		err = s.refreshToken(cred)
 		if err != nil {
@@ -139,5 +179,5 @@ func (s *TTLockAPIService) autoAuth(cred *Credentials, fn func(string, string) (
 		}
 	}
 
-	return response, fmt.Errorf("error response: \"%s\" Body: %s", errorResponse.Errmsg, string(body))
+	return response, fmt.Errorf("error response: \"%w\" Body: %s", newAPIError(errorResponse), string(body))
 }
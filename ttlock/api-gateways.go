@@ -0,0 +1,75 @@
+package ttlock
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	ttlockapi "github.com/nikolai5slo/ttlock2mqtt/ttlock-api"
+)
+
+const gatewaysPageSize = 100
+
+// GetGateways lists all gateways of the account
+func (s *TTLockAPIService) GetGateways(cred Credentials) ([]Gateway, error) {
+	var gateways []Gateway
+
+	for pageNo := int32(1); ; pageNo++ {
+		response, err := s.autoAuth(&cred, func(clientID string, accessToken string) (interface{}, error) {
+			listGatewaysParams := &ttlockapi.ListGatewaysParams{
+				ClientId:    clientID,
+				AccessToken: accessToken,
+				PageNo:      pageNo,
+				PageSize:    gatewaysPageSize,
+				Date:        time.Now().UnixMilli(),
+			}
+
+			return s.ttlockClient.ListGatewaysWithResponse(context.TODO(), listGatewaysParams)
+		}, func(i interface{}) []byte { return i.(*ttlockapi.ListGatewaysResponse).Body }, 0)
+
+		if err != nil {
+			return nil, err
+		}
+
+		page := struct {
+			List  []Gateway `json:"list"`
+			Pages int32     `json:"pages"`
+		}{}
+
+		if err := json.Unmarshal(response.(*ttlockapi.ListGatewaysResponse).Body, &page); err != nil {
+			return nil, err
+		}
+
+		gateways = append(gateways, page.List...)
+
+		if len(page.List) == 0 || pageNo >= page.Pages {
+			return gateways, nil
+		}
+	}
+}
+
+// GetGatewayLocks lists locks in the range of the gateway
+func (s *TTLockAPIService) GetGatewayLocks(cred Credentials, gatewayID int32) ([]GatewayLock, error) {
+	response, err := s.autoAuth(&cred, func(clientID string, accessToken string) (interface{}, error) {
+		listGatewayLocksParams := &ttlockapi.ListGatewayLocksParams{
+			ClientId:    clientID,
+			AccessToken: accessToken,
+			GatewayId:   gatewayID,
+			Date:        time.Now().UnixMilli(),
+		}
+
+		return s.ttlockClient.ListGatewayLocksWithResponse(context.TODO(), listGatewayLocksParams)
+	}, func(i interface{}) []byte { return i.(*ttlockapi.ListGatewayLocksResponse).Body }, 0)
+
+	if err != nil {
+		return nil, err
+	}
+
+	data := struct {
+		List []GatewayLock `json:"list"`
+	}{}
+
+	err = json.Unmarshal(response.(*ttlockapi.ListGatewayLocksResponse).Body, &data)
+
+	return data.List, err
+}
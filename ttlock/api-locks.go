@@ -12,34 +12,57 @@ import (
 	ttlockapi "github.com/nikolai5slo/ttlock2mqtt/ttlock-api"
 )
 
-func (s *TTLockAPIService) GetLocks(cred Credentials) ([]Lock, error) {
-	response, err := s.autoAuth(&cred, func(clientID string, accessToken string) (interface{}, error) {
-		listLocksParams := &ttlockapi.ListLocksParams{
-			ClientId:    clientID,
-			AccessToken: accessToken,
-			LockAlias:   nil,
-			GroupId:     nil,
-			PageNo:      1,
-			PageSize:    100,
-			Date:        time.Now().UnixMilli(),
+const locksPageSize = 100
+
+// GetLocks lists all locks of the account matching the filter
+func (s *TTLockAPIService) GetLocks(cred Credentials, filter LockFilter) ([]Lock, error) {
+	var lockList []Lock
+
+	for pageNo := int32(1); ; pageNo++ {
+		response, err := s.autoAuth(&cred, func(clientID string, accessToken string) (interface{}, error) {
+			listLocksParams := &ttlockapi.ListLocksParams{
+				ClientId:    clientID,
+				AccessToken: accessToken,
+				GroupId:     filter.GroupID,
+				PageNo:      pageNo,
+				PageSize:    locksPageSize,
+				Date:        time.Now().UnixMilli(),
+			}
+
+			if filter.Alias != "" {
+				listLocksParams.LockAlias = &filter.Alias
+			}
+
+			return s.ttlockClient.ListLocksWithResponse(context.TODO(), listLocksParams)
+		}, func(i interface{}) []byte { return i.(*ttlockapi.ListLocksResponse).Body }, 1)
+
+		if err != nil {
+			return nil, err
 		}
 
-		return s.ttlockClient.ListLocksWithResponse(context.TODO(), listLocksParams)
-	}, func(i interface{}) []byte { return i.(*ttlockapi.ListLocksResponse).Body }, 1)
+		body := response.(*ttlockapi.ListLocksResponse).JSON200
 
-	if err != nil {
-		return nil, err
-	}
+		if body == nil {
+			return nil, fmt.Errorf("unexpected lock list response")
+		}
 
-	var lockList []Lock
+		page := struct {
+			List  []Lock
+			Pages int32
+		}{}
 
-	err = mapstructure.Decode((*response.(*ttlockapi.ListLocksResponse).JSON200).(map[string]interface{})["list"], &lockList)
+		err = mapstructure.Decode(*body, &page)
 
-	if err != nil {
-		return nil, err
-	}
+		if err != nil {
+			return nil, err
+		}
+
+		lockList = append(lockList, page.List...)
 
-	return lockList, nil
+		if len(page.List) == 0 || pageNo >= page.Pages {
+			return lockList, nil
+		}
+	}
 }
 
 func (s *TTLockAPIService) GetLockStatus(cred Credentials, l Lock) (LockStatus, error) {
@@ -73,11 +96,42 @@ func (s *TTLockAPIService) GetLockStatus(cred Credentials, l Lock) (LockStatus,
 	return LockStatus(*data.State), nil
 }
 
+func (s *TTLockAPIService) GetLockDetails(cred Credentials, l Lock) (Lock, error) {
+	response, err := s.autoAuth(&cred, func(clientID string, accessToken string) (interface{}, error) {
+		getLockDetailParams := &ttlockapi.GetLockDetailParams{
+			ClientId:    clientID,
+			AccessToken: accessToken,
+			LockId:      l.LockId,
+			Date:        time.Now().UnixMilli(),
+		}
+
+		return s.ttlockClient.GetLockDetailWithResponse(context.TODO(), getLockDetailParams)
+	}, func(i interface{}) []byte { return i.(*ttlockapi.GetLockDetailResponse).Body }, 0)
+
+	if err != nil {
+		return l, err
+	}
+
+	data := Lock{}
+
+	err = json.Unmarshal(response.(*ttlockapi.GetLockDetailResponse).Body, &data)
+
+	if err != nil {
+		return l, err
+	}
+
+	if data.LockId != l.LockId {
+		return l, fmt.Errorf("lock detail mismatch: expected %d, got %d", l.LockId, data.LockId)
+	}
+
+	return data, nil
+}
+
 func (s *TTLockAPIService) Lock(cred Credentials, l Lock) error {
 	_, err := s.autoAuth(&cred, func(clientID string, accessToken string) (interface{}, error) {
 		data := url.Values{}
-		data.Add("clientId", s.clientID)
-		data.Add("accessToken", cred.AccessToken)
+		data.Add("clientId", clientID)
+		data.Add("accessToken", accessToken)
 		data.Add("lockId", fmt.Sprint(l.LockId))
 		data.Add("date", fmt.Sprint(time.Now().UnixMilli()))
 
@@ -90,8 +144,8 @@ func (s *TTLockAPIService) Lock(cred Credentials, l Lock) error {
 func (s *TTLockAPIService) Unlock(cred Credentials, l Lock) error {
 	_, err := s.autoAuth(&cred, func(clientID string, accessToken string) (interface{}, error) {
 		data := url.Values{}
-		data.Add("clientId", s.clientID)
-		data.Add("accessToken", cred.AccessToken)
+		data.Add("clientId", clientID)
+		data.Add("accessToken", accessToken)
 		data.Add("lockId", fmt.Sprint(l.LockId))
 		data.Add("date", fmt.Sprint(time.Now().UnixMilli()))
 
@@ -0,0 +1,102 @@
+package ttlock
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	ttlockapi "github.com/nikolai5slo/ttlock2mqtt/ttlock-api"
+)
+
+// Yes and no values of the passage mode flags
+const (
+	passageYes int32 = 1
+	passageNo  int32 = 2
+)
+
+// GetPassageMode returns the passage mode configuration of the lock
+func (s *TTLockAPIService) GetPassageMode(cred Credentials, l Lock) (PassageMode, error) {
+	response, err := s.autoAuth(&cred, func(clientID string, accessToken string) (interface{}, error) {
+		getPassageModeConfigParams := &ttlockapi.GetPassageModeConfigParams{
+			ClientId:    clientID,
+			AccessToken: accessToken,
+			LockId:      l.LockId,
+			Date:        time.Now().UnixMilli(),
+		}
+
+		return s.ttlockClient.GetPassageModeConfigWithResponse(context.TODO(), getPassageModeConfigParams)
+	}, func(i interface{}) []byte { return i.(*ttlockapi.GetPassageModeConfigResponse).Body }, 0)
+
+	if err != nil {
+		return PassageMode{}, err
+	}
+
+	data := ttlockapi.PassageModeConfig{}
+
+	err = json.Unmarshal(response.(*ttlockapi.GetPassageModeConfigResponse).Body, &data)
+
+	if err != nil {
+		return PassageMode{}, err
+	}
+
+	return passageModeFromAPI(data), nil
+}
+
+// SetPassageMode configures passage mode of the lock via gateway or WiFi
+func (s *TTLockAPIService) SetPassageMode(cred Credentials, l Lock, p PassageMode) error {
+	weekdays, err := json.Marshal(p.Weekdays)
+
+	if err != nil {
+		return err
+	}
+
+	_, err = s.autoAuth(&cred, func(clientID string, accessToken string) (interface{}, error) {
+		data := url.Values{}
+		data.Add("clientId", clientID)
+		data.Add("accessToken", accessToken)
+		data.Add("lockId", fmt.Sprint(l.LockId))
+		data.Add("passageMode", fmt.Sprint(passageFlag(p.Enabled)))
+		data.Add("startDate", fmt.Sprint(p.Start))
+		data.Add("endDate", fmt.Sprint(p.End))
+		data.Add("isAllDay", fmt.Sprint(passageFlag(p.AllDay)))
+		data.Add("weekDays", string(weekdays))
+		data.Add("autoUnlock", fmt.Sprint(passageFlag(p.AutoUnlock)))
+		data.Add("type", remoteOperation)
+		data.Add("date", fmt.Sprint(time.Now().UnixMilli()))
+
+		return s.ttlockClient.ConfigPassageModeWithBodyWithResponse(context.TODO(), "application/x-www-form-urlencoded", strings.NewReader(data.Encode()))
+	}, func(i interface{}) []byte { return i.(*ttlockapi.ConfigPassageModeResponse).Body }, 0)
+
+	return err
+}
+
+func passageFlag(b bool) int32 {
+	if b {
+		return passageYes
+	}
+	return passageNo
+}
+
+func passageModeFromAPI(c ttlockapi.PassageModeConfig) PassageMode {
+	p := PassageMode{
+		Enabled:    c.PassageMode != nil && *c.PassageMode == passageYes,
+		AllDay:     c.IsAllDay != nil && *c.IsAllDay == passageYes,
+		AutoUnlock: c.AutoUnlock != nil && *c.AutoUnlock == passageYes,
+		Weekdays:   []int32{},
+	}
+
+	if c.StartDate != nil {
+		p.Start = *c.StartDate
+	}
+	if c.EndDate != nil {
+		p.End = *c.EndDate
+	}
+	if c.WeekDays != nil {
+		p.Weekdays = *c.WeekDays
+	}
+
+	return p
+}
@@ -0,0 +1,228 @@
+package ttlock
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	ttlockapi "github.com/nikolai5slo/ttlock2mqtt/ttlock-api"
+)
+
+const passcodesPageSize = 100
+
+// Operation types used by the cloud for passcode changes, 2 goes via gateway or WiFi
+const remoteOperation = "2"
+
+// ErrOneTimeCode is returned when a custom code is given for a one-time passcode
+var ErrOneTimeCode = errors.New("one-time passcodes are always generated, leave the code empty")
+
+func (s *TTLockAPIService) GetPasscodes(cred Credentials, l Lock) ([]Passcode, error) {
+	var passcodes []Passcode
+
+	for pageNo := int32(1); ; pageNo++ {
+		response, err := s.autoAuth(&cred, func(clientID string, accessToken string) (interface{}, error) {
+			listPasscodesParams := &ttlockapi.ListPasscodesParams{
+				ClientId:    clientID,
+				AccessToken: accessToken,
+				LockId:      l.LockId,
+				PageNo:      pageNo,
+				PageSize:    passcodesPageSize,
+				Date:        time.Now().UnixMilli(),
+			}
+
+			return s.ttlockClient.ListPasscodesWithResponse(context.TODO(), listPasscodesParams)
+		}, func(i interface{}) []byte { return i.(*ttlockapi.ListPasscodesResponse).Body }, 0)
+
+		if err != nil {
+			return nil, err
+		}
+
+		page := struct {
+			ttlockapi.PaginationInfo
+			List []ttlockapi.Passcode `json:"list"`
+		}{}
+
+		err = json.Unmarshal(response.(*ttlockapi.ListPasscodesResponse).Body, &page)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, p := range page.List {
+			passcodes = append(passcodes, passcodeFromAPI(p))
+		}
+
+		if page.Pages == nil || pageNo >= *page.Pages || len(page.List) == 0 {
+			break
+		}
+	}
+
+	return passcodes, nil
+}
+
+// AddPasscode adds a custom passcode, or generates a random one if the code is empty.
+// One-time passcodes can only be generated.
+func (s *TTLockAPIService) AddPasscode(cred Credentials, l Lock, p Passcode) (Passcode, error) {
+	if p.Type == PasscodeOneTime && p.Code != "" {
+		return p, ErrOneTimeCode
+	}
+
+	if p.Code == "" {
+		return s.generatePasscode(cred, l, p)
+	}
+
+	response, err := s.autoAuth(&cred, func(clientID string, accessToken string) (interface{}, error) {
+		data := url.Values{}
+		data.Add("clientId", clientID)
+		data.Add("accessToken", accessToken)
+		data.Add("lockId", fmt.Sprint(l.LockId))
+		data.Add("keyboardPwd", p.Code)
+		data.Add("keyboardPwdName", p.Name)
+		data.Add("keyboardPwdType", fmt.Sprint(int32(p.Type)))
+		data.Add("startDate", fmt.Sprint(p.startMillis()))
+		data.Add("endDate", fmt.Sprint(p.endMillis()))
+		data.Add("addType", remoteOperation)
+		data.Add("date", fmt.Sprint(time.Now().UnixMilli()))
+
+		return s.ttlockClient.AddPasscodeWithBodyWithResponse(context.TODO(), "application/x-www-form-urlencoded", strings.NewReader(data.Encode()))
+	}, func(i interface{}) []byte { return i.(*ttlockapi.AddPasscodeResponse).Body }, 0)
+
+	if err != nil {
+		return p, err
+	}
+
+	data := ttlockapi.Passcode{}
+
+	err = json.Unmarshal(response.(*ttlockapi.AddPasscodeResponse).Body, &data)
+
+	if err != nil {
+		return p, err
+	}
+
+	if data.KeyboardPwdId != nil {
+		p.ID = *data.KeyboardPwdId
+	}
+	p.LockID = l.LockId
+
+	return p, nil
+}
+
+func (s *TTLockAPIService) generatePasscode(cred Credentials, l Lock, p Passcode) (Passcode, error) {
+	response, err := s.autoAuth(&cred, func(clientID string, accessToken string) (interface{}, error) {
+		generatePasscodeParams := &ttlockapi.GeneratePasscodeParams{
+			ClientId:        clientID,
+			AccessToken:     accessToken,
+			LockId:          l.LockId,
+			KeyboardPwdType: int32(p.Type),
+			KeyboardPwdName: &p.Name,
+			StartDate:       p.startMillis(),
+			Date:            time.Now().UnixMilli(),
+		}
+
+		if p.Type == PasscodeTimed {
+			endDate := p.endMillis()
+			generatePasscodeParams.EndDate = &endDate
+		}
+
+		return s.ttlockClient.GeneratePasscodeWithResponse(context.TODO(), generatePasscodeParams)
+	}, func(i interface{}) []byte { return i.(*ttlockapi.GeneratePasscodeResponse).Body }, 0)
+
+	if err != nil {
+		return p, err
+	}
+
+	data := ttlockapi.Passcode{}
+
+	err = json.Unmarshal(response.(*ttlockapi.GeneratePasscodeResponse).Body, &data)
+
+	if err != nil {
+		return p, err
+	}
+
+	if data.KeyboardPwd == nil {
+		return p, fmt.Errorf("missing passcode in the response")
+	}
+
+	p.Code = *data.KeyboardPwd
+	if data.KeyboardPwdId != nil {
+		p.ID = *data.KeyboardPwdId
+	}
+	p.LockID = l.LockId
+
+	return p, nil
+}
+
+func (s *TTLockAPIService) ChangePasscode(cred Credentials, l Lock, p Passcode) error {
+	_, err := s.autoAuth(&cred, func(clientID string, accessToken string) (interface{}, error) {
+		data := url.Values{}
+		data.Add("clientId", clientID)
+		data.Add("accessToken", accessToken)
+		data.Add("lockId", fmt.Sprint(l.LockId))
+		data.Add("keyboardPwdId", fmt.Sprint(p.ID))
+		data.Add("keyboardPwdName", p.Name)
+		if p.Code != "" {
+			data.Add("newKeyboardPwd", p.Code)
+		}
+		// Dates are left as they are unless set
+		if !p.StartDate.IsZero() {
+			data.Add("startDate", fmt.Sprint(p.startMillis()))
+		}
+		if !p.EndDate.IsZero() {
+			data.Add("endDate", fmt.Sprint(p.endMillis()))
+		}
+		data.Add("changeType", remoteOperation)
+		data.Add("date", fmt.Sprint(time.Now().UnixMilli()))
+
+		return s.ttlockClient.ChangePasscodeWithBodyWithResponse(context.TODO(), "application/x-www-form-urlencoded", strings.NewReader(data.Encode()))
+	}, func(i interface{}) []byte { return i.(*ttlockapi.ChangePasscodeResponse).Body }, 0)
+
+	return err
+}
+
+func (s *TTLockAPIService) DeletePasscode(cred Credentials, l Lock, passcodeID int32) error {
+	_, err := s.autoAuth(&cred, func(clientID string, accessToken string) (interface{}, error) {
+		data := url.Values{}
+		data.Add("clientId", clientID)
+		data.Add("accessToken", accessToken)
+		data.Add("lockId", fmt.Sprint(l.LockId))
+		data.Add("keyboardPwdId", fmt.Sprint(passcodeID))
+		data.Add("deleteType", remoteOperation)
+		data.Add("date", fmt.Sprint(time.Now().UnixMilli()))
+
+		return s.ttlockClient.DeletePasscodeWithBodyWithResponse(context.TODO(), "application/x-www-form-urlencoded", strings.NewReader(data.Encode()))
+	}, func(i interface{}) []byte { return i.(*ttlockapi.DeletePasscodeResponse).Body }, 0)
+
+	return err
+}
+
+func passcodeFromAPI(p ttlockapi.Passcode) Passcode {
+	passcode := Passcode{}
+
+	if p.KeyboardPwdId != nil {
+		passcode.ID = *p.KeyboardPwdId
+	}
+	if p.LockId != nil {
+		passcode.LockID = *p.LockId
+	}
+	if p.KeyboardPwd != nil {
+		passcode.Code = *p.KeyboardPwd
+	}
+	if p.KeyboardPwdName != nil {
+		passcode.Name = *p.KeyboardPwdName
+	}
+	if p.KeyboardPwdType != nil {
+		passcode.Type = PasscodeType(*p.KeyboardPwdType)
+	}
+	if p.StartDate != nil && *p.StartDate > 0 {
+		passcode.StartDate = time.UnixMilli(*p.StartDate)
+	}
+	if p.EndDate != nil && *p.EndDate > 0 {
+		passcode.EndDate = time.UnixMilli(*p.EndDate)
+	}
+
+	return passcode
+}
@@ -0,0 +1,39 @@
+package ttlock
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	ttlockapi "github.com/nikolai5slo/ttlock2mqtt/ttlock-api"
+)
+
+func (s *TTLockAPIService) GetLockRecords(cred Credentials, l Lock, since time.Time, pageNo int32, pageSize int32) (RecordsPage, error) {
+	page := RecordsPage{}
+
+	response, err := s.autoAuth(&cred, func(clientID string, accessToken string) (interface{}, error) {
+		listLockRecordsParams := &ttlockapi.ListLockRecordsParams{
+			ClientId:    clientID,
+			AccessToken: accessToken,
+			LockId:      l.LockId,
+			PageNo:      pageNo,
+			PageSize:    pageSize,
+			Date:        time.Now().UnixMilli(),
+		}
+
+		if !since.IsZero() {
+			startDate := since.UnixMilli()
+			listLockRecordsParams.StartDate = &startDate
+		}
+
+		return s.ttlockClient.ListLockRecordsWithResponse(context.TODO(), listLockRecordsParams)
+	}, func(i interface{}) []byte { return i.(*ttlockapi.ListLockRecordsResponse).Body }, 0)
+
+	if err != nil {
+		return page, err
+	}
+
+	err = json.Unmarshal(response.(*ttlockapi.ListLockRecordsResponse).Body, &page)
+
+	return page, err
+}
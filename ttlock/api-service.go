@@ -6,6 +6,7 @@ type TTLockAPIService struct {
 	ttlockClient ttlockapi.ClientWithResponsesInterface
 	clientID     string
 	clientSecret string
+	tokenSource  TokenSource
 }
 
 type Conf func(*TTLockAPIService) error
@@ -25,6 +26,13 @@ func WithClientSecret(clientID string, clientSecret string) Conf {
 	}
 }
 
+func WithTokenSource(ts TokenSource) Conf {
+	return func(s *TTLockAPIService) error {
+		s.tokenSource = ts
+		return nil
+	}
+}
+
 func New(conf ...Conf) (*TTLockAPIService, error) {
 	service := &TTLockAPIService{}
 
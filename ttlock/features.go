@@ -0,0 +1,33 @@
+package ttlock
+
+import (
+	"math/big"
+	"strings"
+)
+
+// Feature is a bit of the lock feature value
+type Feature uint
+
+const (
+	FeaturePasscode    Feature = 0
+	FeatureCard        Feature = 1
+	FeatureFingerprint Feature = 2
+	FeatureWristband   Feature = 3
+	FeatureAutoLock    Feature = 4
+	FeaturePassageMode Feature = 22
+)
+
+// SupportsFeature reports whether the feature bit is set in the hexadecimal feature value of the lock
+func SupportsFeature(l Lock, f Feature) bool {
+	if l.FeatureValue == nil {
+		return false
+	}
+
+	value, ok := new(big.Int).SetString(strings.TrimSpace(*l.FeatureValue), 16)
+
+	if !ok {
+		return false
+	}
+
+	return value.Bit(int(f)) == 1
+}
@@ -0,0 +1,112 @@
+package ttlock
+
+import "time"
+
+// Record is a lock operation record as reported by the TTLock cloud
+type Record struct {
+	RecordId           int64  `json:"recordId,omitempty"`
+	LockId             int32  `json:"lockId"`
+	RecordType         int32  `json:"recordType"`
+	RecordTypeFromLock int32  `json:"recordTypeFromLock,omitempty"`
+	Success            int32  `json:"success"`
+	Username           string `json:"username,omitempty"`
+	KeyboardPwd        string `json:"keyboardPwd,omitempty"`
+	LockDate           int64  `json:"lockDate"`
+	ServerDate         int64  `json:"serverDate,omitempty"`
+	ElectricQuantity   *int32 `json:"electricQuantity,omitempty"`
+}
+
+// RecordsPage is a single page of lock records
+type RecordsPage struct {
+	List     []Record `json:"list"`
+	PageNo   int32    `json:"pageNo"`
+	PageSize int32    `json:"pageSize"`
+	Pages    int32    `json:"pages"`
+	Total    int32    `json:"total"`
+}
+
+var recordMethods = map[int32]string{
+	1:  "app",
+	3:  "gateway",
+	4:  "passcode",
+	7:  "card",
+	8:  "fingerprint",
+	9:  "wristband",
+	10: "key",
+	11: "app",
+	12: "gateway",
+	32: "inside",
+	33: "fingerprint",
+	34: "passcode",
+	35: "card",
+	36: "key",
+	37: "remote",
+	45: "auto",
+	46: "button",
+	47: "button",
+}
+
+var recordStatuses = map[int32]LockStatus{
+	1:  Unlocked,
+	3:  Unlocked,
+	4:  Unlocked,
+	7:  Unlocked,
+	8:  Unlocked,
+	9:  Unlocked,
+	10: Unlocked,
+	12: Unlocked,
+	32: Unlocked,
+	37: Unlocked,
+	46: Unlocked,
+	11: Locked,
+	33: Locked,
+	34: Locked,
+	35: Locked,
+	36: Locked,
+	45: Locked,
+	47: Locked,
+}
+
+// Method returns how the lock was operated (app, passcode, card, fingerprint...)
+func (r Record) Method() string {
+	if m, ok := recordMethods[r.RecordType]; ok {
+		return m
+	}
+	return "unknown"
+}
+
+// LockStatus returns the status the lock ended up in after the record, or Unknown
+// if the record does not change the lock state
+func (r Record) LockStatus() LockStatus {
+	if !r.Succeeded() {
+		return Unknown
+	}
+	if s, ok := recordStatuses[r.RecordType]; ok {
+		return s
+	}
+	return Unknown
+}
+
+// EventType classifies the record as unlock, lock, failed or other
+func (r Record) EventType() string {
+	if !r.Succeeded() {
+		return "failed"
+	}
+
+	switch r.LockStatus() {
+	case Unlocked:
+		return "unlock"
+	case Locked:
+		return "lock"
+	}
+
+	return "other"
+}
+
+func (r Record) Succeeded() bool {
+	return r.Success == 1
+}
+
+func (r Record) Time() time.Time {
+	return time.UnixMilli(r.LockDate)
+}
@@ -1,9 +1,21 @@
 package ttlock
 
+import "time"
+
 type Service interface {
-	GetLocks(Credentials) ([]Lock, error)
+	GetLocks(cred Credentials, filter LockFilter) ([]Lock, error)
 	Login(string, string) (Credentials, error)
 	GetLockStatus(cred Credentials, l Lock) (LockStatus, error)
+	GetLockDetails(cred Credentials, l Lock) (Lock, error)
+	GetLockRecords(cred Credentials, l Lock, since time.Time, pageNo int32, pageSize int32) (RecordsPage, error)
 	Lock(cred Credentials, l Lock) error
 	Unlock(cred Credentials, l Lock) error
+	GetPasscodes(cred Credentials, l Lock) ([]Passcode, error)
+	AddPasscode(cred Credentials, l Lock, p Passcode) (Passcode, error)
+	ChangePasscode(cred Credentials, l Lock, p Passcode) error
+	DeletePasscode(cred Credentials, l Lock, passcodeID int32) error
+	GetPassageMode(cred Credentials, l Lock) (PassageMode, error)
+	SetPassageMode(cred Credentials, l Lock, p PassageMode) error
+	GetGateways(cred Credentials) ([]Gateway, error)
+	GetGatewayLocks(cred Credentials, gatewayID int32) ([]GatewayLock, error)
 }
@@ -12,6 +12,11 @@ const (
 	Locked   LockStatus = 0
 	Unlocked LockStatus = 1
 	Unknown  LockStatus = 2
+
+	// Reported by the bridge, not by TTLock
+	Locking   LockStatus = 3
+	Unlocking LockStatus = 4
+	Jammed    LockStatus = 5
 )
 
 type Credentials struct {
@@ -20,6 +25,115 @@ type Credentials struct {
 	RefreshToken string
 	AccessToken  string
 	ExpiresAt    time.Time
+
+	// Refresh token was rejected, user has to login again
+	NeedsLogin bool
+}
+
+// TokenSource keeps access tokens valid and persisted
+type TokenSource interface {
+	// Token returns the freshest known credentials, refreshing them ahead of expiry
+	Token(cred Credentials) (Credentials, error)
+	// Refresh forces a token refresh, e.g. after the API rejected the token
+	Refresh(cred Credentials) (Credentials, error)
+}
+
+// APIError is an error reported by the TTLock API
+type APIError struct {
+	Code    int32
+	Message string
+}
+
+func newAPIError(e ttlockapi.Error) *APIError {
+	apiErr := &APIError{Message: e.Errmsg}
+	if e.Errcode != nil {
+		apiErr.Code = *e.Errcode
+	}
+	return apiErr
+}
+
+func (e *APIError) Error() string {
+	return e.Message
+}
+
+// Error codes meaning the token or grant is not valid anymore and the user has to login again
+var invalidGrantCodes = map[int32]bool{
+	10003: true, // invalid token
+	10004: true, // invalid grant
+	10007: true, // invalid account or password
+	10011: true, // invalid refresh token
+}
+
+// InvalidGrant reports whether the error rejects the credentials, other errors may be transient
+func (e *APIError) InvalidGrant() bool {
+	return invalidGrantCodes[e.Code]
 }
 
 type Lock = ttlockapi.Lock
+
+type Gateway = ttlockapi.Gateway
+
+type GatewayLock = ttlockapi.GatewayLock
+
+// LockFilter narrows down the account lock list, zero value matches all locks
+type LockFilter struct {
+	// Fuzzy match on lock alias
+	Alias   string
+	GroupID *int32
+}
+
+type PasscodeType int32
+
+const (
+	PasscodeOneTime   PasscodeType = 1
+	PasscodePermanent PasscodeType = 2
+	PasscodeTimed     PasscodeType = 3
+)
+
+func (t PasscodeType) String() string {
+	switch t {
+	case PasscodeOneTime:
+		return "one-time"
+	case PasscodePermanent:
+		return "permanent"
+	case PasscodeTimed:
+		return "timed"
+	}
+	return "other"
+}
+
+type Passcode struct {
+	ID        int32
+	LockID    int32
+	Code      string
+	Name      string
+	Type      PasscodeType
+	StartDate time.Time
+	EndDate   time.Time
+}
+
+func (p Passcode) startMillis() int64 {
+	if p.StartDate.IsZero() {
+		return time.Now().UnixMilli()
+	}
+	return p.StartDate.UnixMilli()
+}
+
+func (p Passcode) endMillis() int64 {
+	if p.EndDate.IsZero() {
+		return 0
+	}
+	return p.EndDate.UnixMilli()
+}
+
+// PassageMode keeps the lock unlocked during the configured hours
+type PassageMode struct {
+	Enabled    bool
+	AllDay     bool
+	AutoUnlock bool
+	// Minutes from midnight
+	Start int32
+	End   int32
+	// 1 is Monday, 7 is Sunday
+	Weekdays []int32
+}